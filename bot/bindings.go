@@ -0,0 +1,156 @@
+package bot
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/ulstu-schedule/parser/types"
+)
+
+// Binding is the group or teacher chosen in the chat.
+type Binding struct {
+	Entity types.Entity
+	// SubGroup is the number of the subgroup of the group, which lessons are shown with the lessons common for the
+	// group. All the lessons are shown if 0.
+	SubGroup int
+}
+
+// Bindings keeps the group or teacher chosen in the chat, which schedule is shown by the commands without arguments.
+type Bindings interface {
+	// Binding returns the binding of the chat. Returns false if the chat has no binding.
+	Binding(chatID int64) (Binding, bool, error)
+	// Bind binds the group or teacher to the chat, replacing the previous binding.
+	Bind(chatID int64, binding Binding) error
+}
+
+// MemoryBindings keeps the bindings in memory, so they are lost after restart.
+type MemoryBindings struct {
+	mu       sync.RWMutex
+	bindings map[int64]Binding
+}
+
+// NewMemoryBindings returns *MemoryBindings without bindings.
+func NewMemoryBindings() *MemoryBindings {
+	return &MemoryBindings{bindings: map[int64]Binding{}}
+}
+
+// Binding returns the binding of the chat.
+func (b *MemoryBindings) Binding(chatID int64) (Binding, bool, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	binding, ok := b.bindings[chatID]
+	return binding, ok, nil
+}
+
+// Bind binds the group or teacher to the chat.
+func (b *MemoryBindings) Bind(chatID int64, binding Binding) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.bindings[chatID] = binding
+	return nil
+}
+
+// fileBinding is the binding in the file of FileBindings.
+type fileBinding struct {
+	Type     types.ScheduleType `json:"type"`
+	Name     string             `json:"name"`
+	SubGroup int                `json:"sub_group,omitempty"`
+}
+
+// newFileBinding returns the binding in the file of FileBindings.
+func newFileBinding(binding Binding) fileBinding {
+	return fileBinding{Type: binding.Entity.Type, Name: binding.Entity.Name, SubGroup: binding.SubGroup}
+}
+
+// FileBindings keeps the bindings in memory and writes all of them to the JSON file after each change.
+type FileBindings struct {
+	path string
+
+	mu       sync.RWMutex
+	bindings map[int64]Binding
+}
+
+// NewFileBindings returns *FileBindings with the bindings read from the file. The file is created on the first
+// change if it does not exist.
+func NewFileBindings(path string) (*FileBindings, error) {
+	b := &FileBindings{path: path, bindings: map[int64]Binding{}}
+
+	data, err := ioutil.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fileBindings := map[string]fileBinding{}
+	if err = json.Unmarshal(data, &fileBindings); err != nil {
+		return nil, err
+	}
+	for chatIDStr, binding := range fileBindings {
+		chatID, err := strconv.ParseInt(chatIDStr, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		b.bindings[chatID] = Binding{Entity: types.Entity{Type: binding.Type, Name: binding.Name},
+			SubGroup: binding.SubGroup}
+	}
+	return b, nil
+}
+
+// Binding returns the binding of the chat.
+func (b *FileBindings) Binding(chatID int64) (Binding, bool, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	binding, ok := b.bindings[chatID]
+	return binding, ok, nil
+}
+
+// Bind binds the group or teacher to the chat and writes the bindings to the file. The binding is not changed if the
+// file cannot be written.
+func (b *FileBindings) Bind(chatID int64, binding Binding) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	fileBindings := make(map[string]fileBinding, len(b.bindings)+1)
+	for boundChatID, boundBinding := range b.bindings {
+		fileBindings[strconv.FormatInt(boundChatID, 10)] = newFileBinding(boundBinding)
+	}
+	fileBindings[strconv.FormatInt(chatID, 10)] = newFileBinding(binding)
+
+	data, err := json.Marshal(fileBindings)
+	if err != nil {
+		return err
+	}
+
+	// the file is renamed after writing, so the incomplete file is never read
+	tmpFile, err := ioutil.TempFile(filepath.Dir(b.path), "*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmpFile.Name())
+	}()
+
+	if _, err = tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		return err
+	}
+	if err = tmpFile.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmpFile.Name(), b.path); err != nil {
+		return err
+	}
+
+	b.bindings[chatID] = binding
+	return nil
+}
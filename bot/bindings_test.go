@@ -0,0 +1,60 @@
+package bot
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+func testBindings(t *testing.T, b Bindings) {
+	t.Helper()
+
+	_, ok, err := b.Binding(1)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NoError(t, b.Bind(1, Binding{Entity: types.Entity{Type: types.Group, Name: "АТсд-21"}}))
+	assert.NoError(t, b.Bind(-100, Binding{Entity: types.Entity{Type: types.Teacher, Name: "Зенкина С М"}}))
+	assert.NoError(t, b.Bind(1, Binding{Entity: types.Entity{Type: types.Group, Name: "ПИбд-11"}, SubGroup: 2}))
+
+	binding, ok, err := b.Binding(1)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.EqualValues(t, Binding{Entity: types.Entity{Type: types.Group, Name: "ПИбд-11"}, SubGroup: 2}, binding)
+
+	binding, _, _ = b.Binding(-100)
+	assert.EqualValues(t, Binding{Entity: types.Entity{Type: types.Teacher, Name: "Зенкина С М"}}, binding)
+}
+
+func TestMemoryBindings(t *testing.T) {
+	testBindings(t, NewMemoryBindings())
+}
+
+func TestFileBindings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bindings.json")
+
+	b, err := NewFileBindings(path)
+	assert.NoError(t, err)
+	testBindings(t, b)
+
+	// the bindings are read after restart
+	b, err = NewFileBindings(path)
+	assert.NoError(t, err)
+	binding, ok, err := b.Binding(-100)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.EqualValues(t, Binding{Entity: types.Entity{Type: types.Teacher, Name: "Зенкина С М"}}, binding)
+	binding, _, _ = b.Binding(1)
+	assert.EqualValues(t, 2, binding.SubGroup)
+
+	files, err := ioutil.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`{"chat": {"type": 0, "name": "АТсд-21"}}`), 0o644))
+	_, err = NewFileBindings(path)
+	assert.Error(t, err)
+}
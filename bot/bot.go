@@ -0,0 +1,376 @@
+// Package bot implements the commands of the schedule chat bots independently of the messenger. The messenger
+// adapters pass the messages and the presses of the inline buttons to Handler and send its responses.
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ulstu-schedule/parser/schedule"
+	"github.com/ulstu-schedule/parser/storage"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+// MaxCallbackDataLen is the maximum length of the data of the button in bytes. The buttons with longer data are not
+// added to the keyboards, because the messengers reject them.
+const MaxCallbackDataLen = 64
+
+const (
+	dayCallback  = "day"
+	weekCallback = "week"
+	// callbackSep separates the parts of the callback data: "day:1:0:АТсд-21" is the day schedule of the group
+	// АТсд-21 for tomorrow, "day:1:0:2:АТсд-21" is the same for its second subgroup
+	callbackSep = ":"
+)
+
+const helpText = `Бот показывает расписание УлГТУ.
+
+/group АТсд-21 — выбрать группу
+/group АТсд-21 2 — выбрать группу и подгруппу
+/teacher Зенкина С М — выбрать преподавателя
+/today — расписание на сегодня
+/tomorrow — расписание на завтра
+/week — расписание на текущую неделю
+/nextweek — расписание на следующую неделю
+/room 6-401 — расписание аудитории на сегодня`
+
+// Button is the inline button attached to the message. Data is passed to Handler.HandleCallback when it is pressed.
+type Button struct {
+	Text string
+	Data string
+}
+
+// Keyboard is the rows of the inline buttons.
+type Keyboard [][]Button
+
+// Response is the message sent by the bot in response to the command or the button press.
+type Response struct {
+	Text     string // caption of the photo if Photo is set
+	Photo    []byte // PNG image with the week schedule
+	Keyboard Keyboard
+	// ShowCommands is set when the messenger adapters should show the persistent keyboard with the commands of the
+	// chosen schedule, e.g. after the group is chosen
+	ShowCommands bool
+}
+
+// Handler handles the commands of the chats. The group and teacher schedules are fetched from UlSTU site, the room
+// schedules and the schedules unavailable on the site are loaded from the storage.
+type Handler struct {
+	bindings Bindings
+	store    storage.Storage
+	fetch    func(entity types.Entity) (*types.Schedule, error)
+}
+
+// NewHandler returns *Handler that keeps the chosen groups and teachers in bindings. The store may be nil, then the
+// room schedules are not available.
+func NewHandler(bindings Bindings, store storage.Storage) *Handler {
+	return &Handler{bindings: bindings, store: store, fetch: schedule.GetFullEntitySchedule}
+}
+
+// HandleMessage returns the response to the text message from the chat. The commands can have the bot name suffix,
+// e.g. "/today@ulstu_schedule_bot".
+func (h *Handler) HandleMessage(chatID int64, text string) Response {
+	command, arg := parseCommand(text)
+
+	switch command {
+	case "start", "help":
+		return Response{Text: helpText, ShowCommands: true}
+	case "group":
+		binding, ok := parseGroupBinding(arg)
+		if !ok {
+			return Response{Text: "Номер подгруппы должен быть положительным, например: /group АТсд-21 2"}
+		}
+		return h.bind(chatID, binding)
+	case "teacher":
+		return h.bind(chatID, Binding{Entity: types.Entity{Type: types.Teacher, Name: arg}})
+	case "room":
+		if arg == "" {
+			return Response{Text: "Укажите аудиторию, например: /room 6-401"}
+		}
+		return h.getDayResponse(Binding{Entity: types.Entity{Type: types.Room, Name: arg}}, 0)
+	case "today", "tomorrow", "week", "nextweek":
+		binding, ok, err := h.bindings.Binding(chatID)
+		if err != nil {
+			return Response{Text: "Не удалось получить выбранную группу, попробуйте позже"}
+		}
+		if !ok {
+			return Response{Text: "Сначала выберите группу или преподавателя, например: /group АТсд-21"}
+		}
+
+		switch command {
+		case "today":
+			return h.getDayResponse(binding, 0)
+		case "tomorrow":
+			return h.getDayResponse(binding, 1)
+		case "week":
+			return h.getWeekResponse(binding, false)
+		default:
+			return h.getWeekResponse(binding, true)
+		}
+	default:
+		return Response{Text: "Неизвестная команда\n\n" + helpText}
+	}
+}
+
+// HandleCallback returns the response to the press of the inline button with the data. The messenger adapters replace
+// the message with the pressed button by the text response and send the response with the photo as a new message.
+func (h *Handler) HandleCallback(data string) Response {
+	parts := strings.SplitN(data, callbackSep, 5)
+	if len(parts) < 4 {
+		return Response{Text: "Кнопка устарела, отправьте команду ещё раз"}
+	}
+
+	offset, offsetErr := strconv.Atoi(parts[1])
+	typeSchedule, typeErr := strconv.Atoi(parts[2])
+	if offsetErr != nil || typeErr != nil {
+		return Response{Text: "Кнопка устарела, отправьте команду ещё раз"}
+	}
+	binding := Binding{Entity: types.Entity{Type: types.ScheduleType(typeSchedule), Name: parts[3]}}
+
+	// the buttons of the subgroups have the number of the subgroup before the name
+	if len(parts) == 5 {
+		subGroup, err := strconv.Atoi(parts[3])
+		if err != nil || subGroup <= 0 {
+			return Response{Text: "Кнопка устарела, отправьте команду ещё раз"}
+		}
+		binding.Entity.Name, binding.SubGroup = parts[4], subGroup
+	}
+
+	switch parts[0] {
+	case dayCallback:
+		return h.getDayResponse(binding, offset)
+	case weekCallback:
+		return h.getWeekResponse(binding, offset > 0)
+	default:
+		return Response{Text: "Кнопка устарела, отправьте команду ещё раз"}
+	}
+}
+
+// bind binds the group or teacher to the chat if its schedule exists and returns the schedule for today.
+func (h *Handler) bind(chatID int64, binding Binding) Response {
+	entity := binding.Entity
+	if entity.Name == "" {
+		if entity.Type == types.Teacher {
+			return Response{Text: "Укажите преподавателя, например: /teacher Зенкина С М"}
+		}
+		return Response{Text: "Укажите группу, например: /group АТсд-21"}
+	}
+
+	fullSchedule, err := h.getSchedule(entity)
+	if err != nil {
+		return Response{Text: getErrorText(entity, err)}
+	}
+	if err = h.bindings.Bind(chatID, binding); err != nil {
+		return Response{Text: "Не удалось сохранить выбор, попробуйте позже"}
+	}
+
+	boundName := entity.Name
+	if binding.SubGroup > 0 {
+		boundName = fmt.Sprintf("%s, %d подгруппа", entity.Name, binding.SubGroup)
+	}
+
+	// the schedule is already fetched to check the name, so it is not fetched again
+	res := getScheduleDayResponse(fullSchedule, binding, 0)
+	res.Text = fmt.Sprintf("Выбрано расписание: %s\n\n%s", boundName, res.Text)
+	res.ShowCommands = true
+	return res
+}
+
+// getDayResponse returns the text of the day schedule with the keyboard for the navigation between the days.
+func (h *Handler) getDayResponse(binding Binding, daysAfterCurr int) Response {
+	fullSchedule, err := h.getSchedule(binding.Entity)
+	if err != nil {
+		return Response{Text: getErrorText(binding.Entity, err)}
+	}
+	return getScheduleDayResponse(fullSchedule, binding, daysAfterCurr)
+}
+
+// getScheduleDayResponse returns the response of getDayResponse for the full schedule of the group or teacher of the
+// binding.
+func getScheduleDayResponse(fullSchedule *types.Schedule, binding Binding, daysAfterCurr int) Response {
+	entity := binding.Entity
+	day, err := schedule.ParseDaySchedule(filterBindingSchedule(fullSchedule, binding), entity.Name, daysAfterCurr)
+	if err != nil {
+		return Response{Text: getErrorText(entity, err), Keyboard: getDayKeyboard(binding, daysAfterCurr)}
+	}
+
+	return Response{
+		Text:     schedule.ConvertDayScheduleToText(day, entity.Name, entity.Type, daysAfterCurr),
+		Keyboard: getDayKeyboard(binding, daysAfterCurr),
+	}
+}
+
+// getWeekResponse returns the image with the schedule of the current or the next week.
+func (h *Handler) getWeekResponse(binding Binding, isNextWeek bool) Response {
+	entity := binding.Entity
+	fullSchedule, err := h.getSchedule(entity)
+	if err != nil {
+		return Response{Text: getErrorText(entity, err)}
+	}
+	fullSchedule = filterBindingSchedule(fullSchedule, binding)
+
+	var (
+		week    *types.Week
+		caption string
+	)
+	if isNextWeek {
+		week, err = schedule.ParseNextWeekSchedule(fullSchedule, entity.Name)
+		caption = fmt.Sprintf("Расписание %s на следующую неделю", entity.Name)
+	} else {
+		week, err = schedule.ParseCurrWeekSchedule(fullSchedule, entity.Name)
+		caption = fmt.Sprintf("Расписание %s на текущую неделю", entity.Name)
+	}
+	if err != nil {
+		return Response{Text: getErrorText(entity, err)}
+	}
+
+	img, err := schedule.RenderWeekScheduleImg(week, entity.Name, entity.Type, !isNextWeek)
+	if err != nil {
+		return Response{Text: getErrorText(entity, err)}
+	}
+	return Response{Text: caption, Photo: img, Keyboard: getWeekKeyboard(binding, isNextWeek)}
+}
+
+// filterBindingSchedule returns the full schedule of the group of the binding with only the lessons of the subgroup
+// and the lessons common for the group if the subgroup is chosen, otherwise - the schedule as is.
+func filterBindingSchedule(fullSchedule *types.Schedule, binding Binding) *types.Schedule {
+	if binding.Entity.Type == types.Group && binding.SubGroup > 0 {
+		return schedule.FilterSubGroup(fullSchedule, binding.SubGroup)
+	}
+	return fullSchedule
+}
+
+// getSchedule returns the full schedule of the entity.
+func (h *Handler) getSchedule(entity types.Entity) (*types.Schedule, error) {
+	if entity.Type == types.Room {
+		return h.loadSchedule(entity)
+	}
+
+	fullSchedule, err := h.fetch(entity)
+	if errors.Is(err, types.ErrSiteUnavailable) && h.store != nil {
+		if storedSchedule, loadErr := h.loadSchedule(entity); loadErr == nil {
+			return storedSchedule, nil
+		}
+	}
+	return fullSchedule, err
+}
+
+// loadSchedule returns the latest schedule of the entity from the storage.
+func (h *Handler) loadSchedule(entity types.Entity) (*types.Schedule, error) {
+	if h.store == nil {
+		return nil, &types.NotStoredError{Entity: entity}
+	}
+
+	storedSchedule, _, err := h.store.Load(entity, time.Time{})
+	return storedSchedule, err
+}
+
+// getDayKeyboard returns the keyboard with the buttons of the previous day, today and the next day. Returns nil if
+// the name of the entity is too long for the data of the buttons.
+func getDayKeyboard(binding Binding, daysAfterCurr int) Keyboard {
+	row := []Button{
+		{Text: "◀", Data: getCallbackData(dayCallback, daysAfterCurr-1, binding)},
+		{Text: "Сегодня", Data: getCallbackData(dayCallback, 0, binding)},
+		{Text: "▶", Data: getCallbackData(dayCallback, daysAfterCurr+1, binding)},
+	}
+	for _, button := range row {
+		if len(button.Data) > MaxCallbackDataLen {
+			return nil
+		}
+	}
+	return Keyboard{row}
+}
+
+// getWeekKeyboard returns the keyboard with the button of the other week. Returns nil if the name of the entity is too
+// long for the data of the button.
+func getWeekKeyboard(binding Binding, isNextWeek bool) Keyboard {
+	button := Button{Text: "Следующая неделя ▶", Data: getCallbackData(weekCallback, 1, binding)}
+	if isNextWeek {
+		button = Button{Text: "◀ Текущая неделя", Data: getCallbackData(weekCallback, 0, binding)}
+	}
+
+	if len(button.Data) > MaxCallbackDataLen {
+		return nil
+	}
+	return Keyboard{{button}}
+}
+
+// getCallbackData returns the data of the button that shows the schedule of the group or teacher of the binding.
+func getCallbackData(kind string, offset int, binding Binding) string {
+	parts := []string{kind, strconv.Itoa(offset), strconv.Itoa(int(binding.Entity.Type))}
+	if binding.SubGroup > 0 {
+		parts = append(parts, strconv.Itoa(binding.SubGroup))
+	}
+	return strings.Join(append(parts, binding.Entity.Name), callbackSep)
+}
+
+// parseGroupBinding returns the binding of the group from the argument of the group command: the name of the group
+// optionally followed by the number of the subgroup, e.g. "АТсд-21 2". Returns false if the number of the subgroup is
+// not positive.
+func parseGroupBinding(arg string) (Binding, bool) {
+	binding := Binding{Entity: types.Entity{Type: types.Group, Name: arg}}
+
+	fields := strings.Fields(arg)
+	if len(fields) < 2 {
+		return binding, true
+	}
+
+	subGroup, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil {
+		return binding, true
+	}
+	if subGroup <= 0 {
+		return binding, false
+	}
+
+	binding.Entity.Name, binding.SubGroup = strings.Join(fields[:len(fields)-1], " "), subGroup
+	return binding, true
+}
+
+// parseCommand returns the command without the slash and the bot name, and its argument.
+func parseCommand(text string) (string, string) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "/") {
+		return "", text
+	}
+
+	command, arg := text[1:], ""
+	if sepIdx := strings.IndexAny(command, " \n"); sepIdx != -1 {
+		command, arg = command[:sepIdx], strings.Join(strings.Fields(command[sepIdx+1:]), " ")
+	}
+	if botIdx := strings.Index(command, "@"); botIdx != -1 {
+		command = command[:botIdx]
+	}
+	return strings.ToLower(command), arg
+}
+
+// getErrorText returns the message about the error for the users.
+func getErrorText(entity types.Entity, err error) string {
+	var notFoundErr *types.NotFoundError
+
+	switch {
+	case errors.As(err, &notFoundErr):
+		var text string
+		switch entity.Type {
+		case types.Teacher:
+			text = fmt.Sprintf("Преподаватель %s не найден", entity.Name)
+		default:
+			text = fmt.Sprintf("Группа %s не найдена", entity.Name)
+		}
+		if len(notFoundErr.Suggestions) > 0 {
+			text += ". Возможно, вы имели в виду: " + strings.Join(notFoundErr.Suggestions, ", ")
+		}
+		return text
+	case errors.Is(err, types.ErrNotStored):
+		return fmt.Sprintf("Расписание аудитории %s неизвестно", entity.Name)
+	case errors.Is(err, types.ErrNotPublished):
+		return "Расписание ещё не опубликовано"
+	case errors.Is(err, types.ErrSiteUnavailable):
+		return "Сайт с расписанием недоступен, попробуйте позже"
+	default:
+		return "Не удалось получить расписание, попробуйте позже"
+	}
+}
@@ -0,0 +1,183 @@
+package bot
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/mock"
+	"github.com/ulstu-schedule/parser/storage"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+func newTestHandler(t *testing.T, store storage.Storage) *Handler {
+	t.Helper()
+
+	h := NewHandler(NewMemoryBindings(), store)
+	h.fetch = func(entity types.Entity) (*types.Schedule, error) {
+		switch entity {
+		case types.Entity{Type: types.Group, Name: "АТсд-21"}:
+			return mock.TestGroupSchedule(t), nil
+		case types.Entity{Type: types.Teacher, Name: "Зенкина С М"}:
+			return mock.TestTeacherSchedule(t), nil
+		case types.Entity{Type: types.Group, Name: "ИСТбд-11"}:
+			return nil, &types.RequestError{URL: "https://lk.ulstu.ru", Err: http.ErrHandlerTimeout}
+		default:
+			return nil, &types.NotFoundError{Name: entity.Name, Type: entity.Type, Suggestions: []string{"АТсд-21"}}
+		}
+	}
+	return h
+}
+
+func TestHandlerGroup(t *testing.T) {
+	h := newTestHandler(t, nil)
+
+	res := h.HandleMessage(1, "/today")
+	assert.True(t, strings.HasPrefix(res.Text, "Сначала выберите группу"))
+
+	res = h.HandleMessage(1, "/group ПИбд-99")
+	assert.EqualValues(t, "Группа ПИбд-99 не найдена. Возможно, вы имели в виду: АТсд-21", res.Text)
+	_, ok, _ := h.bindings.Binding(1)
+	assert.False(t, ok)
+
+	// the schedule is fetched once to check the name and to show the day
+	fetch, fetchesNum := h.fetch, 0
+	h.fetch = func(entity types.Entity) (*types.Schedule, error) {
+		fetchesNum++
+		return fetch(entity)
+	}
+	res = h.HandleMessage(1, "/group   АТсд-21")
+	assert.EqualValues(t, 1, fetchesNum)
+	assert.True(t, strings.HasPrefix(res.Text, "Выбрано расписание: АТсд-21\n\nРасписание АТсд-21 на сегодня"))
+	assert.True(t, res.ShowCommands)
+	assert.EqualValues(t, Keyboard{{
+		{Text: "◀", Data: "day:-1:0:АТсд-21"},
+		{Text: "Сегодня", Data: "day:0:0:АТсд-21"},
+		{Text: "▶", Data: "day:1:0:АТсд-21"},
+	}}, res.Keyboard)
+
+	res = h.HandleMessage(1, "/today@ulstu_schedule_bot")
+	assert.True(t, strings.HasPrefix(res.Text, "Расписание АТсд-21 на сегодня"))
+	assert.False(t, res.ShowCommands)
+
+	res = h.HandleMessage(1, "/tomorrow")
+	assert.True(t, strings.HasPrefix(res.Text, "Расписание АТсд-21 на завтра"))
+
+	// other chats do not share the binding
+	res = h.HandleMessage(2, "/tomorrow")
+	assert.True(t, strings.HasPrefix(res.Text, "Сначала выберите группу"))
+}
+
+func TestHandlerSubGroup(t *testing.T) {
+	h := newTestHandler(t, nil)
+
+	res := h.HandleMessage(1, "/group АТсд-21 2")
+	assert.True(t, strings.HasPrefix(res.Text, "Выбрано расписание: АТсд-21, 2 подгруппа\n\nРасписание АТсд-21"))
+	assert.EqualValues(t, "day:1:0:2:АТсд-21", res.Keyboard[0][2].Data)
+	binding, _, _ := h.bindings.Binding(1)
+	assert.EqualValues(t, Binding{Entity: types.Entity{Type: types.Group, Name: "АТсд-21"}, SubGroup: 2}, binding)
+
+	assert.True(t, strings.HasPrefix(h.HandleMessage(1, "/group АТсд-21 0").Text, "Номер подгруппы"))
+
+	// the lessons of the first subgroup on Monday of the 11th week are not shown to the second one
+	subGroupSchedule := filterBindingSchedule(mock.TestGroupSchedule(t), binding)
+	monday := subGroupSchedule.Weeks[0].Days[0]
+	assert.Empty(t, monday.Lessons[1].SubLessons)
+	assert.Len(t, monday.Lessons[2].SubLessons, 1)
+
+	res = h.HandleMessage(1, "/week")
+	assert.NotEmpty(t, res.Photo)
+	assert.EqualValues(t, "week:1:0:2:АТсд-21", res.Keyboard[0][0].Data)
+
+	// the subgroup is kept by the buttons
+	res = h.HandleCallback("day:1:0:2:АТсд-21")
+	assert.EqualValues(t, "day:2:0:2:АТсд-21", res.Keyboard[0][2].Data)
+	assert.True(t, strings.HasPrefix(h.HandleCallback("day:1:0:x:АТсд-21").Text, "Кнопка устарела"))
+}
+
+func TestHandlerWeek(t *testing.T) {
+	h := newTestHandler(t, nil)
+	assert.NoError(t, h.bindings.Bind(1, Binding{Entity: types.Entity{Type: types.Group, Name: "АТсд-21"}}))
+
+	res := h.HandleMessage(1, "/week")
+	assert.EqualValues(t, "Расписание АТсд-21 на текущую неделю", res.Text)
+	assert.True(t, bytes.HasPrefix(res.Photo, []byte("\x89PNG")))
+	assert.EqualValues(t, Keyboard{{{Text: "Следующая неделя ▶", Data: "week:1:0:АТсд-21"}}}, res.Keyboard)
+
+	res = h.HandleMessage(1, "/nextweek")
+	assert.EqualValues(t, "Расписание АТсд-21 на следующую неделю", res.Text)
+	assert.NotEmpty(t, res.Photo)
+
+	res = h.HandleCallback("week:0:0:АТсд-21")
+	assert.EqualValues(t, "Расписание АТсд-21 на текущую неделю", res.Text)
+}
+
+func TestHandlerCallback(t *testing.T) {
+	h := newTestHandler(t, nil)
+
+	res := h.HandleCallback("day:1:1:Зенкина С М")
+	assert.True(t, strings.HasPrefix(res.Text, "Зенкина С М проводит следующие пары завтра"))
+	assert.EqualValues(t, "day:2:1:Зенкина С М", res.Keyboard[0][2].Data)
+
+	assert.True(t, strings.HasPrefix(h.HandleCallback("day:1").Text, "Кнопка устарела"))
+	assert.True(t, strings.HasPrefix(h.HandleCallback("month:1:0:АТсд-21").Text, "Кнопка устарела"))
+	assert.True(t, strings.HasPrefix(h.HandleCallback("day:x:0:АТсд-21").Text, "Кнопка устарела"))
+}
+
+func TestHandlerStorage(t *testing.T) {
+	t.Run("no storage", func(t *testing.T) {
+		h := newTestHandler(t, nil)
+
+		assert.EqualValues(t, "Расписание аудитории 6-401 неизвестно", h.HandleMessage(1, "/room 6-401").Text)
+		assert.EqualValues(t, "Сайт с расписанием недоступен, попробуйте позже",
+			h.HandleMessage(1, "/group ИСТбд-11").Text)
+	})
+	t.Run("storage", func(t *testing.T) {
+		store := storage.NewMemoryStorage()
+		assert.NoError(t, store.Save(types.Entity{Type: types.Room, Name: "6-401"}, mock.TestRoomSchedule(t),
+			time.Now()))
+		assert.NoError(t, store.Save(types.Entity{Type: types.Group, Name: "ИСТбд-11"}, mock.TestGroupSchedule(t),
+			time.Now()))
+		h := newTestHandler(t, store)
+
+		assert.True(t, strings.HasPrefix(h.HandleMessage(1, "/room 6-401").Text, "Расписание кабинента 6-401"))
+		assert.True(t, strings.HasPrefix(h.HandleMessage(1, "/group ИСТбд-11").Text, "Выбрано расписание: ИСТбд-11"))
+	})
+}
+
+func TestHandlerHelp(t *testing.T) {
+	h := newTestHandler(t, nil)
+
+	assert.EqualValues(t, helpText, h.HandleMessage(1, "/start").Text)
+	assert.True(t, strings.HasPrefix(h.HandleMessage(1, "/month").Text, "Неизвестная команда"))
+	assert.True(t, strings.HasPrefix(h.HandleMessage(1, "/teacher").Text, "Укажите преподавателя"))
+	assert.True(t, strings.HasPrefix(h.HandleMessage(1, "/room").Text, "Укажите аудиторию"))
+}
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		text, command, arg string
+	}{
+		{text: "/today", command: "today"},
+		{text: " /Today@ulstu_schedule_bot ", command: "today"},
+		{text: "/teacher Зенкина  С М", command: "teacher", arg: "Зенкина С М"},
+		{text: "/group@ulstu_schedule_bot АТсд-21", command: "group", arg: "АТсд-21"},
+		{text: "АТсд-21", arg: "АТсд-21"},
+		{text: "/group АТсд-21  2", command: "group", arg: "АТсд-21 2"},
+	}
+	for _, tt := range tests {
+		command, arg := parseCommand(tt.text)
+		assert.EqualValues(t, tt.command, command, tt.text)
+		assert.EqualValues(t, tt.arg, arg, tt.text)
+	}
+}
+
+func TestGetDayKeyboard(t *testing.T) {
+	assert.Len(t, getDayKeyboard(Binding{Entity: types.Entity{Type: types.Teacher, Name: "Преподаватели кафедры"}}, 0),
+		1)
+	assert.Nil(t, getDayKeyboard(Binding{Entity: types.Entity{Type: types.Teacher, Name: "Преподаватели кафедры " +
+		"Измерительно-вычислительных комплексов"}}, 0))
+}
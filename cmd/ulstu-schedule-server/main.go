@@ -0,0 +1,133 @@
+// Command ulstu-schedule-server serves the schedules of UlSTU groups, teachers and rooms over HTTP and, optionally,
+// gRPC.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/ulstu-schedule/parser/schedule"
+	"github.com/ulstu-schedule/parser/schedulepb"
+	"github.com/ulstu-schedule/parser/server"
+	"github.com/ulstu-schedule/parser/storage"
+	"github.com/ulstu-schedule/parser/types"
+	"google.golang.org/grpc"
+)
+
+// options are the command-line options of the server.
+type options struct {
+	addr          string
+	grpcAddr      string
+	cacheTTL      time.Duration
+	watchInterval time.Duration
+	dbPath        string
+	crawlInterval time.Duration
+	crawlWorkers  int
+}
+
+func main() {
+	opts := options{}
+	flag.StringVar(&opts.addr, "addr", ":8080", "address to listen on")
+	flag.StringVar(&opts.grpcAddr, "grpc-addr", "", "address to serve the gRPC API on, disabled if empty")
+	flag.DurationVar(&opts.cacheTTL, "cache-ttl", 10*time.Minute, "time to cache the schedules fetched from the site")
+	flag.DurationVar(&opts.watchInterval, "watch-interval", 15*time.Minute, "delay between the checks of the "+
+		"schedules watched by the gRPC clients")
+	flag.StringVar(&opts.dbPath, "db", "", "path to the SQLite database with the stored schedules, used for the "+
+		"rooms and when the site is unavailable")
+	flag.DurationVar(&opts.crawlInterval, "crawl-interval", 0, "delay between the crawls that save the schedules "+
+		"of all the groups, teachers and rooms to the database, disabled if 0")
+	flag.IntVar(&opts.crawlWorkers, "crawl-workers", 4, "number of the schedules downloaded at the same time by "+
+		"the crawler")
+	flag.Parse()
+
+	if err := run(opts); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run serves the APIs until one of the servers fails.
+func run(opts options) error {
+	if opts.crawlInterval > 0 && opts.dbPath == "" {
+		return errors.New("-crawl-interval requires -db")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var store storage.Storage
+	if opts.dbPath != "" {
+		sqliteStore, err := storage.NewSQLiteStorage(opts.dbPath)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = sqliteStore.Close()
+		}()
+		store = sqliteStore
+	}
+
+	apiServer := server.NewServer(store, opts.cacheTTL)
+	errs := make(chan error, 2)
+
+	if opts.crawlInterval > 0 {
+		crawler := schedule.NewCrawler(opts.crawlWorkers)
+		go func() {
+			_ = server.RunCrawler(ctx, crawler, store, opts.crawlInterval,
+				func(entityErrs []*types.EntityError, err error) {
+					if err != nil {
+						log.Printf("crawl: %v", err)
+						return
+					}
+					log.Printf("crawl: saved the schedules, %d failed to download", len(entityErrs))
+				})
+		}()
+	}
+
+	if opts.grpcAddr != "" {
+		listener, err := net.Listen("tcp", opts.grpcAddr)
+		if err != nil {
+			return err
+		}
+
+		// the watcher keeps the last seen versions in memory, so the stored schedules are not replaced by it
+		watcher := schedule.NewWatcher(storage.NewMemoryStorage(), opts.watchInterval)
+		watcher.Jitter = opts.watchInterval / 10
+		watcher.OnError = func(entity types.Entity, err error) {
+			log.Printf("watch %s: %v", entity, err)
+		}
+
+		service := server.NewGRPCService(apiServer, watcher)
+		watcher.OnChange = service.Notify
+
+		go func() {
+			_ = watcher.Run(ctx)
+		}()
+
+		grpcServer := grpc.NewServer()
+		schedulepb.RegisterScheduleServiceServer(grpcServer, service)
+		defer grpcServer.Stop()
+
+		log.Printf("serving gRPC on %s", opts.grpcAddr)
+		go func() {
+			errs <- grpcServer.Serve(listener)
+		}()
+	}
+
+	httpServer := &http.Server{
+		Addr:         opts.addr,
+		Handler:      apiServer,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: time.Minute,
+	}
+
+	log.Printf("listening on %s", opts.addr)
+	go func() {
+		errs <- httpServer.ListenAndServe()
+	}()
+	return <-errs
+}
@@ -0,0 +1,83 @@
+// Command ulstu-schedule-telegram runs the Telegram bot that shows the schedules of UlSTU groups, teachers and rooms.
+// The token of the bot is read from the TELEGRAM_BOT_TOKEN environment variable.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/ulstu-schedule/parser/bot"
+	"github.com/ulstu-schedule/parser/storage"
+	"github.com/ulstu-schedule/parser/telegram"
+)
+
+// tokenEnv is the environment variable with the token of the bot.
+const tokenEnv = "TELEGRAM_BOT_TOKEN"
+
+// options are the command-line options of the bot.
+type options struct {
+	bindingsPath string
+	dbPath       string
+}
+
+func main() {
+	opts := options{}
+	flag.StringVar(&opts.bindingsPath, "bindings", "", "path to the JSON file with the groups chosen in the chats, "+
+		"kept in memory if empty")
+	flag.StringVar(&opts.dbPath, "db", "", "path to the SQLite database with the stored schedules, used for the "+
+		"rooms and when the site is unavailable")
+	flag.Parse()
+
+	if err := run(opts); err != nil && !errors.Is(err, context.Canceled) {
+		log.Fatal(err)
+	}
+}
+
+// run receives the updates of the bot until the interrupt signal.
+func run(opts options) error {
+	token := os.Getenv(tokenEnv)
+	if token == "" {
+		return errors.New(tokenEnv + " is not set")
+	}
+
+	var bindings bot.Bindings = bot.NewMemoryBindings()
+	if opts.bindingsPath != "" {
+		fileBindings, err := bot.NewFileBindings(opts.bindingsPath)
+		if err != nil {
+			return err
+		}
+		bindings = fileBindings
+	}
+
+	var store storage.Storage
+	if opts.dbPath != "" {
+		sqliteStore, err := storage.NewSQLiteStorage(opts.dbPath)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = sqliteStore.Close()
+		}()
+		store = sqliteStore
+	}
+
+	client := telegram.NewClient(token)
+	client.HTTPClient = &http.Client{Timeout: time.Minute}
+
+	tgBot := telegram.NewBot(client, bot.NewHandler(bindings, store))
+	tgBot.OnError = func(err error) {
+		log.Print(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	log.Print("receiving updates")
+	return tgBot.Run(ctx)
+}
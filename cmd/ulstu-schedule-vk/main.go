@@ -0,0 +1,92 @@
+// Command ulstu-schedule-vk runs the callback server of the VK community bot that shows the schedules of UlSTU groups,
+// teachers and rooms. The access token of the community, the confirmation string and the secret key of Callback API
+// are read from the VK_TOKEN, VK_CONFIRMATION and VK_SECRET environment variables.
+package main
+
+import (
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/ulstu-schedule/parser/bot"
+	"github.com/ulstu-schedule/parser/storage"
+	"github.com/ulstu-schedule/parser/vk"
+)
+
+const (
+	tokenEnv        = "VK_TOKEN"
+	confirmationEnv = "VK_CONFIRMATION"
+	secretEnv       = "VK_SECRET"
+)
+
+// options are the command-line options of the bot.
+type options struct {
+	addr         string
+	bindingsPath string
+	dbPath       string
+}
+
+func main() {
+	opts := options{}
+	flag.StringVar(&opts.addr, "addr", ":8080", "address to receive the events of Callback API on")
+	flag.StringVar(&opts.bindingsPath, "bindings", "", "path to the JSON file with the groups chosen in the chats, "+
+		"kept in memory if empty")
+	flag.StringVar(&opts.dbPath, "db", "", "path to the SQLite database with the stored schedules, used for the "+
+		"rooms and when the site is unavailable")
+	flag.Parse()
+
+	if err := run(opts); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run serves Callback API until the server fails.
+func run(opts options) error {
+	token, confirmation := os.Getenv(tokenEnv), os.Getenv(confirmationEnv)
+	if token == "" || confirmation == "" {
+		return errors.New(tokenEnv + " and " + confirmationEnv + " must be set")
+	}
+
+	var bindings bot.Bindings = bot.NewMemoryBindings()
+	if opts.bindingsPath != "" {
+		fileBindings, err := bot.NewFileBindings(opts.bindingsPath)
+		if err != nil {
+			return err
+		}
+		bindings = fileBindings
+	}
+
+	var store storage.Storage
+	if opts.dbPath != "" {
+		sqliteStore, err := storage.NewSQLiteStorage(opts.dbPath)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = sqliteStore.Close()
+		}()
+		store = sqliteStore
+	}
+
+	client := vk.NewClient(token)
+	client.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+	vkBot := vk.NewBot(client, bot.NewHandler(bindings, store), confirmation)
+	vkBot.Secret = os.Getenv(secretEnv)
+	vkBot.OnError = func(err error) {
+		log.Print(err)
+	}
+
+	httpServer := &http.Server{
+		Addr:         opts.addr,
+		Handler:      vkBot,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: time.Minute,
+	}
+
+	log.Printf("listening on %s", opts.addr)
+	return httpServer.ListenAndServe()
+}
@@ -0,0 +1,425 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ulstu-schedule/parser/schedule"
+	"github.com/ulstu-schedule/parser/storage"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+const (
+	textFormat = "text"
+	jsonFormat = "json"
+	pngFormat  = "png"
+	icsFormat  = "ics"
+)
+
+// entityTypes maps the schedule commands to the types of the schedule.
+var entityTypes = map[string]types.ScheduleType{"group": types.Group, "teacher": types.Teacher, "room": types.Room}
+
+// scheduleFlags are the flags of the group, teacher and room commands.
+type scheduleFlags struct {
+	configPath string
+	dbPath     string
+	format     string
+	output     string
+	today      bool
+	tomorrow   bool
+	date       string
+	week       string
+	subGroup   int
+}
+
+// these are replaced in tests
+var (
+	now           = time.Now
+	fetchSchedule = schedule.GetFullEntitySchedule
+	listGroups    = schedule.GetGroupsInfo
+	listTeachers  = schedule.GetTeachersInfo
+)
+
+// runSchedule shows the day or week schedule of the group, teacher or room.
+func runSchedule(command string, args []string, w io.Writer) error {
+	f := &scheduleFlags{}
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	fs.SetOutput(w)
+	fs.StringVar(&f.configPath, "config", "", "path to the config file")
+	fs.StringVar(&f.dbPath, "db", "", "path to the SQLite database with the stored schedules")
+	fs.StringVar(&f.format, "format", "", "output format: text, json, png or ics (default text)")
+	fs.StringVar(&f.output, "output", "", "path to the PNG image (default <name>.png)")
+	fs.BoolVar(&f.today, "today", false, "show the schedule for today (default)")
+	fs.BoolVar(&f.tomorrow, "tomorrow", false, "show the schedule for tomorrow")
+	fs.StringVar(&f.date, "date", "", "show the schedule for the date in the YYYY-MM-DD or DD.MM.YYYY format")
+	fs.StringVar(&f.week, "week", "", "show the week schedule: curr or next")
+	fs.IntVar(&f.subGroup, "subgroup", 0, "show only the lessons of the subgroup and the lessons common for the group")
+
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(getConfigPath(f.configPath))
+	if err != nil {
+		return err
+	}
+	applyConfig(f, cfg)
+
+	entity := types.Entity{Type: entityTypes[command], Name: strings.Join(positional, " ")}
+	if entity.Name == "" {
+		entity.Name = getDefaultName(cfg, entity.Type)
+	}
+	// the subgroup from the config belongs to the group from the config
+	if f.subGroup == 0 && entity.Type == types.Group && entity.Name == cfg.Group {
+		f.subGroup = cfg.SubGroup
+	}
+	if f.subGroup < 0 {
+		return &types.IncorrectSubGroupError{SubGroup: strconv.Itoa(f.subGroup)}
+	}
+	if entity.Name == "" {
+		return fmt.Errorf("the %s name is not set in the arguments or the config", command)
+	}
+
+	date, err := getScheduleDate(f)
+	if err != nil {
+		return err
+	}
+
+	fullSchedule, err := getEntitySchedule(entity, f.dbPath)
+	if err != nil {
+		return err
+	}
+	if f.subGroup > 0 {
+		fullSchedule = schedule.FilterSubGroup(fullSchedule, f.subGroup)
+	}
+
+	switch f.format {
+	case textFormat:
+		return writeText(w, fullSchedule, entity, date, f.week != "")
+	case jsonFormat:
+		return writeJSON(w, fullSchedule, entity, date, f.week != "")
+	case pngFormat:
+		return writePNG(w, fullSchedule, entity, date, f.output)
+	case icsFormat:
+		_, err = fmt.Fprint(w, schedule.ConvertScheduleToICS(fullSchedule, entity.Name, entity.Type))
+		return err
+	default:
+		return fmt.Errorf("unknown format %q", f.format)
+	}
+}
+
+// runGroups shows the list of the groups.
+func runGroups(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("groups", flag.ContinueOnError)
+	fs.SetOutput(w)
+	format := fs.String("format", textFormat, "output format: text or json")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	groups, err := listGroups()
+	if err != nil {
+		return err
+	}
+
+	if *format == jsonFormat {
+		return writeIndentedJSON(w, groups)
+	}
+	for _, group := range groups {
+		if _, err = fmt.Fprintf(w, "%s\t%d курс\t%s\t%s\n", group.Name, group.Course, group.StudyForm,
+			group.Faculties); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runTeachers shows the list of the teachers.
+func runTeachers(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("teachers", flag.ContinueOnError)
+	fs.SetOutput(w)
+	format := fs.String("format", textFormat, "output format: text or json")
+	department := fs.String("department", "", "show only the teachers of the department")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	teachers, err := listTeachers()
+	if err != nil {
+		return err
+	}
+	if *department != "" {
+		teachers = schedule.GetTeachersByDepartment(teachers, *department)
+	}
+
+	if *format == jsonFormat {
+		return writeIndentedJSON(w, teachers)
+	}
+	for _, teacher := range teachers {
+		if _, err = fmt.Fprintf(w, "%s\t%s\n", teacher.Name, strings.Join(teacher.Details, ", ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runSearch shows the groups and teachers with the names similar to the query.
+func runSearch(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	fs.SetOutput(w)
+	searchType := fs.String("type", "", "search only the groups or the teachers: group or teacher")
+	limit := fs.Int("limit", 5, "maximum number of the found names of each type")
+
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	query := strings.Join(positional, " ")
+	if query == "" {
+		return errors.New("the search query is not set")
+	}
+
+	found := false
+	if *searchType == "" || *searchType == "group" {
+		groups, err := listGroups()
+		if err != nil {
+			return err
+		}
+
+		names := make([]string, 0, len(groups))
+		for _, group := range groups {
+			names = append(names, group.Name)
+		}
+		candidates := schedule.NewResolver(types.Group, names).Resolve(query, *limit)
+		found = found || len(candidates) > 0
+		if err = writeCandidates(w, "группа", candidates); err != nil {
+			return err
+		}
+	}
+	if *searchType == "" || *searchType == "teacher" {
+		teachers, err := listTeachers()
+		if err != nil {
+			return err
+		}
+
+		names := make([]string, 0, len(teachers))
+		for _, teacher := range teachers {
+			names = append(names, teacher.Name)
+		}
+		candidates := schedule.NewResolver(types.Teacher, names).Resolve(query, *limit)
+		found = found || len(candidates) > 0
+		if err = writeCandidates(w, "преподаватель", candidates); err != nil {
+			return err
+		}
+	}
+
+	if !found {
+		_, err = fmt.Fprintf(w, "По запросу %q ничего не найдено\n", query)
+	}
+	return err
+}
+
+// parseFlags parses the flags that may be placed after the positional arguments and returns the positional ones.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	positional := make([]string, 0)
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// applyConfig sets the flags that were not set to the values from the config.
+func applyConfig(f *scheduleFlags, cfg *config) {
+	if f.format == "" {
+		f.format = cfg.Format
+	}
+	if f.format == "" {
+		f.format = textFormat
+	}
+	if f.dbPath == "" {
+		f.dbPath = cfg.DB
+	}
+}
+
+// getDefaultName returns the name of the group, teacher or room from the config.
+func getDefaultName(cfg *config, typeSchedule types.ScheduleType) string {
+	switch typeSchedule {
+	case types.Group:
+		return cfg.Group
+	case types.Teacher:
+		return cfg.Teacher
+	default:
+		return cfg.Room
+	}
+}
+
+// getScheduleDate returns the date of the requested schedule.
+func getScheduleDate(f *scheduleFlags) (time.Time, error) {
+	date := now()
+
+	switch {
+	case f.date != "":
+		parsedDate, err := parseDate(f.date)
+		if err != nil {
+			return time.Time{}, err
+		}
+		date = parsedDate
+	case f.tomorrow:
+		date = date.AddDate(0, 0, 1)
+	}
+
+	switch f.week {
+	case "", "curr", "current":
+	case "next":
+		date = date.AddDate(0, 0, 7)
+	default:
+		return time.Time{}, fmt.Errorf("unknown week %q, expected curr or next", f.week)
+	}
+	return date, nil
+}
+
+// parseDate returns the noon of the date in the YYYY-MM-DD or DD.MM.YYYY format in the local time zone.
+func parseDate(date string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "02.01.2006"} {
+		if parsedDate, err := time.ParseInLocation(layout, date, time.Local); err == nil {
+			return parsedDate.Add(12 * time.Hour), nil
+		}
+	}
+	return time.Time{}, &types.IncorrectDateError{Date: date}
+}
+
+// getEntitySchedule returns the full schedule of the group or teacher from UlSTU site, or the schedule of the room
+// from the storage.
+func getEntitySchedule(entity types.Entity, dbPath string) (*types.Schedule, error) {
+	if entity.Type != types.Room {
+		return fetchSchedule(entity)
+	}
+
+	if dbPath == "" {
+		return nil, errors.New("the room schedules are available only from the storage, set --db or db in the config")
+	}
+	store, err := storage.NewSQLiteStorage(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	fullSchedule, _, err := store.Load(entity, time.Time{})
+	return fullSchedule, err
+}
+
+// writeText writes the day schedule, or the schedules of the days of the week, as text.
+func writeText(w io.Writer, fullSchedule *types.Schedule, entity types.Entity, date time.Time, isWeek bool) error {
+	if !isWeek {
+		day, err := schedule.ParseDayScheduleByDate(fullSchedule, entity.Name, date)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, schedule.ConvertDayScheduleToText(day, entity.Name, entity.Type, getDaysAfterCurr(date)))
+		return err
+	}
+
+	week, err := schedule.ParseWeekScheduleByDate(fullSchedule, entity.Name, date)
+	if err != nil {
+		return err
+	}
+
+	monday := date.AddDate(0, 0, -getWeekDayNum(date))
+	dayTexts := make([]string, 0, 6)
+	// sunday is not a school day
+	for dayIdx := 0; dayIdx < 6; dayIdx++ {
+		dayTexts = append(dayTexts, schedule.ConvertDayScheduleToText(&week.Days[dayIdx], entity.Name, entity.Type,
+			getDaysAfterCurr(monday.AddDate(0, 0, dayIdx))))
+	}
+	_, err = fmt.Fprintln(w, strings.Join(dayTexts, "\n\n"))
+	return err
+}
+
+// writeJSON writes the day or week schedule as JSON.
+func writeJSON(w io.Writer, fullSchedule *types.Schedule, entity types.Entity, date time.Time, isWeek bool) error {
+	if isWeek {
+		week, err := schedule.ParseWeekScheduleByDate(fullSchedule, entity.Name, date)
+		if err != nil {
+			return err
+		}
+		return writeIndentedJSON(w, week)
+	}
+
+	day, err := schedule.ParseDayScheduleByDate(fullSchedule, entity.Name, date)
+	if err != nil {
+		return err
+	}
+	return writeIndentedJSON(w, day)
+}
+
+// writePNG saves the image with the week schedule to the output path and writes the path.
+func writePNG(w io.Writer, fullSchedule *types.Schedule, entity types.Entity, date time.Time, output string) error {
+	week, err := schedule.ParseWeekScheduleByDate(fullSchedule, entity.Name, date)
+	if err != nil {
+		return err
+	}
+
+	currYear, currWeek := now().ISOWeek()
+	year, weekNum := date.ISOWeek()
+	img, err := schedule.RenderWeekScheduleImg(week, entity.Name, entity.Type, currYear == year && currWeek == weekNum)
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		output = strings.ReplaceAll(entity.Name, "/", "_") + ".png"
+	}
+	if err = ioutil.WriteFile(output, img, 0o644); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, output)
+	return err
+}
+
+// writeIndentedJSON writes the value as the indented JSON.
+func writeIndentedJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// writeCandidates writes the found names with their similarity to the query.
+func writeCandidates(w io.Writer, kind string, candidates []types.Candidate) error {
+	for _, candidate := range candidates {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%.0f%%\n", candidate.Name, kind, candidate.Score*100); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getDaysAfterCurr returns the number of the calendar days from today to the date.
+func getDaysAfterCurr(date time.Time) int {
+	year, month, day := now().Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	year, month, day = date.Date()
+	return int(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Sub(today).Hours() / 24)
+}
+
+// getWeekDayNum returns the index of the day of the week of the date starting from Monday.
+func getWeekDayNum(date time.Time) int {
+	return (int(date.Weekday()) + 6) % 7
+}
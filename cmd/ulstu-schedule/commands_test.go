@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/mock"
+	"github.com/ulstu-schedule/parser/storage"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+func setTestSources(t *testing.T) {
+	t.Helper()
+
+	prevNow, prevFetch, prevListGroups, prevListTeachers := now, fetchSchedule, listGroups, listTeachers
+	t.Cleanup(func() {
+		now, fetchSchedule, listGroups, listTeachers = prevNow, prevFetch, prevListGroups, prevListTeachers
+	})
+
+	now = func() time.Time {
+		return time.Date(2024, 4, 16, 10, 0, 0, 0, time.Local)
+	}
+	fetchSchedule = func(entity types.Entity) (*types.Schedule, error) {
+		if entity.Type == types.Teacher {
+			return mock.TestTeacherSchedule(t), nil
+		}
+		if entity.Name != "АТсд-21" {
+			return nil, &types.NotFoundError{Name: entity.Name, Type: entity.Type}
+		}
+		return mock.TestGroupSchedule(t), nil
+	}
+	listGroups = func() ([]types.GroupInfo, error) {
+		return []types.GroupInfo{{Name: "АТсд-21", Course: 2}, {Name: "ПИбд-11", Course: 1}}, nil
+	}
+	listTeachers = func() ([]types.TeacherInfo, error) {
+		return []types.TeacherInfo{{Name: "Зенкина С М", Department: "Дизайн", Details: []string{"Дизайн"}}}, nil
+	}
+
+	// the config of the user must not affect the tests
+	t.Setenv(configEnv, filepath.Join(t.TempDir(), "config.json"))
+}
+
+func TestRunSchedule(t *testing.T) {
+	setTestSources(t)
+
+	t.Run("day text", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		assert.NoError(t, run([]string{"group", "АТсд-21", "--tomorrow"}, out))
+		assert.Contains(t, out.String(), "Шарафутдинова Н С")
+	})
+	t.Run("week json", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		assert.NoError(t, run([]string{"teacher", "Зенкина", "С", "М", "--date", "2024-04-10", "--week", "curr",
+			"--format", "json"}, out))
+
+		var week types.Week
+		assert.NoError(t, json.Unmarshal(out.Bytes(), &week))
+		assert.EqualValues(t, 10, week.Number)
+	})
+	t.Run("next week text", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		assert.NoError(t, run([]string{"group", "--week", "next", "АТсд-21"}, out))
+		assert.Contains(t, out.String(), "Спецглавы математики")
+	})
+	t.Run("ics", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		assert.NoError(t, run([]string{"group", "АТсд-21", "--format", "ics"}, out))
+		assert.Contains(t, out.String(), "BEGIN:VCALENDAR")
+	})
+	t.Run("png", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "week.png")
+		// the image is rendered in memory, nothing is written to the working directory
+		workDir := mock.ChdirTemp(t)
+		out := &bytes.Buffer{}
+		assert.NoError(t, run([]string{"group", "АТсд-21", "--format", "png", "--output", output}, out))
+
+		img, err := ioutil.ReadFile(output)
+		assert.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(img, []byte("\x89PNG")))
+
+		workDirFiles, err := ioutil.ReadDir(workDir)
+		assert.NoError(t, err)
+		assert.Empty(t, workDirFiles)
+	})
+	t.Run("config defaults", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), "config.json")
+		assert.NoError(t, ioutil.WriteFile(configPath, []byte(`{"group": "АТсд-21", "format": "json"}`), 0o644))
+
+		out := &bytes.Buffer{}
+		assert.NoError(t, run([]string{"group", "--config", configPath, "--date", "25.04.2024"}, out))
+
+		var day types.Day
+		assert.NoError(t, json.Unmarshal(out.Bytes(), &day))
+		assert.EqualValues(t, 12, day.WeekNumber)
+	})
+	t.Run("subgroup", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		assert.NoError(t, run([]string{"group", "АТсд-21", "--date", "2024-04-15", "--subgroup", "2", "--format",
+			"json"}, out))
+
+		var day types.Day
+		assert.NoError(t, json.Unmarshal(out.Bytes(), &day))
+		assert.Empty(t, day.Lessons[1].SubLessons)
+		assert.Len(t, day.Lessons[2].SubLessons, 1)
+
+		// the subgroup from the config is used only for the group from the config
+		configPath := filepath.Join(t.TempDir(), "config.json")
+		assert.NoError(t, ioutil.WriteFile(configPath, []byte(`{"group": "АТсд-21", "subgroup": 1}`), 0o644))
+
+		out.Reset()
+		assert.NoError(t, run([]string{"group", "--config", configPath, "--date", "2024-04-15", "--format", "json"},
+			out))
+		day = types.Day{}
+		assert.NoError(t, json.Unmarshal(out.Bytes(), &day))
+		assert.Len(t, day.Lessons[1].SubLessons, 1)
+		assert.Empty(t, day.Lessons[2].SubLessons)
+	})
+	t.Run("room from storage", func(t *testing.T) {
+		dbPath := filepath.Join(t.TempDir(), "schedules.db")
+		store, err := storage.NewSQLiteStorage(dbPath)
+		assert.NoError(t, err)
+		assert.NoError(t, store.Save(types.Entity{Type: types.Room, Name: "6-401"}, mock.TestGroupSchedule(t),
+			time.Now()))
+		assert.NoError(t, store.Close())
+
+		out := &bytes.Buffer{}
+		assert.NoError(t, run([]string{"room", "6-401", "--db", dbPath, "--date", "2024-04-16"}, out))
+		assert.Contains(t, out.String(), "Расписание кабинента 6-401")
+	})
+	t.Run("errors", func(t *testing.T) {
+		assert.Error(t, run([]string{"group"}, &bytes.Buffer{}))
+		assert.Error(t, run([]string{"group", "АТсд-21", "--format", "pdf"}, &bytes.Buffer{}))
+		assert.Error(t, run([]string{"group", "АТсд-21", "--date", "16/04"}, &bytes.Buffer{}))
+		assert.Error(t, run([]string{"group", "АТсд-21", "--week", "prev"}, &bytes.Buffer{}))
+		assert.Error(t, run([]string{"room", "6-401"}, &bytes.Buffer{}))
+		assert.Error(t, run([]string{"group", "ПИбд-99"}, &bytes.Buffer{}))
+		assert.Error(t, run([]string{"faculty"}, &bytes.Buffer{}))
+		assert.Error(t, run([]string{"group", "АТсд-21", "--subgroup", "-1"}, &bytes.Buffer{}))
+	})
+}
+
+func TestRunLists(t *testing.T) {
+	setTestSources(t)
+
+	out := &bytes.Buffer{}
+	assert.NoError(t, run([]string{"groups"}, out))
+	assert.EqualValues(t, "АТсд-21\t2 курс\tочная\t\nПИбд-11\t1 курс\tочная\t\n", out.String())
+
+	out.Reset()
+	assert.NoError(t, run([]string{"teachers", "--department", "Дизайн", "--format", "json"}, out))
+	var teachers []types.TeacherInfo
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &teachers))
+	assert.Len(t, teachers, 1)
+
+	out.Reset()
+	assert.NoError(t, run([]string{"search", "атсд21"}, out))
+	assert.Contains(t, out.String(), "АТсд-21\tгруппа")
+
+	out.Reset()
+	assert.NoError(t, run([]string{"search", "--type", "teacher", "Иванов"}, out))
+	assert.Contains(t, out.String(), "ничего не найдено")
+}
+
+func TestParseFlags(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	tomorrow := fs.Bool("tomorrow", false, "")
+	format := fs.String("format", "", "")
+
+	positional, err := parseFlags(fs, []string{"Зенкина", "--tomorrow", "С", "М", "--format", "json"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"Зенкина", "С", "М"}, positional)
+	assert.True(t, *tomorrow)
+	assert.EqualValues(t, "json", *format)
+}
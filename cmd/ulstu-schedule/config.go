@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// configEnv is the environment variable with the path to the config file.
+const configEnv = "ULSTU_SCHEDULE_CONFIG"
+
+// config contains the defaults used when the flags and arguments are not set.
+type config struct {
+	Group    string `json:"group"`    // group shown by "ulstu-schedule group" without the name
+	SubGroup int    `json:"subgroup"` // subgroup of the group from the config, all the subgroups are shown if 0
+	Teacher  string `json:"teacher"`  // teacher shown by "ulstu-schedule teacher" without the name
+	Room     string `json:"room"`     // room shown by "ulstu-schedule room" without the name
+	Format   string `json:"format"`   // output format: text, json, png or ics
+	DB       string `json:"db"`       // path to the SQLite database with the stored schedules, required for the rooms
+}
+
+// getConfigPath returns the path to the config file: the flag value, the environment variable or the default path in
+// the user config directory.
+func getConfigPath(flagPath string) string {
+	if flagPath != "" {
+		return flagPath
+	}
+	if envPath := os.Getenv(configEnv); envPath != "" {
+		return envPath
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "ulstu-schedule", "config.json")
+}
+
+// loadConfig returns the config from the file at path. The empty config is returned if the file does not exist.
+func loadConfig(path string) (*config, error) {
+	cfg := &config{}
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
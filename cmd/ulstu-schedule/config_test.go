@@ -0,0 +1,39 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		assert.NoError(t, ioutil.WriteFile(path, []byte(`{"group": "АТсд-21", "db": "schedules.db"}`), 0o644))
+
+		cfg, err := loadConfig(path)
+		assert.NoError(t, err)
+		assert.EqualValues(t, &config{Group: "АТсд-21", DB: "schedules.db"}, cfg)
+	})
+	t.Run("no file", func(t *testing.T) {
+		cfg, err := loadConfig(filepath.Join(t.TempDir(), "config.json"))
+		assert.NoError(t, err)
+		assert.EqualValues(t, &config{}, cfg)
+	})
+	t.Run("incorrect file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		assert.NoError(t, ioutil.WriteFile(path, []byte(`{"group":`), 0o644))
+
+		_, err := loadConfig(path)
+		assert.Error(t, err)
+	})
+}
+
+func TestGetConfigPath(t *testing.T) {
+	t.Setenv(configEnv, "/etc/ulstu-schedule.json")
+
+	assert.EqualValues(t, "config.json", getConfigPath("config.json"))
+	assert.EqualValues(t, "/etc/ulstu-schedule.json", getConfigPath(""))
+}
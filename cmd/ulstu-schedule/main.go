@@ -0,0 +1,62 @@
+// Command ulstu-schedule shows the schedules of UlSTU groups, teachers and rooms in the terminal.
+//
+// Usage:
+//
+//	ulstu-schedule group [name] [--today | --tomorrow | --date 2006-01-02] [--week curr|next] [--format text|json|png|ics]
+//	ulstu-schedule teacher [name] [flags]
+//	ulstu-schedule room [name] [flags]
+//	ulstu-schedule groups [--format text|json]
+//	ulstu-schedule teachers [--department name] [--format text|json]
+//	ulstu-schedule search <query> [--type group|teacher]
+//
+// The defaults are read from the JSON config file (see config), its path is set by --config, the
+// ULSTU_SCHEDULE_CONFIG environment variable or is "ulstu-schedule/config.json" in the user config directory.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `Usage:
+  ulstu-schedule group [name] [flags]      schedule of the group
+  ulstu-schedule teacher [name] [flags]    schedule of the teacher
+  ulstu-schedule room [name] [flags]       schedule of the room (requires the stored schedules, see --db)
+  ulstu-schedule groups [flags]            list of the groups
+  ulstu-schedule teachers [flags]          list of the teachers
+  ulstu-schedule search <query> [flags]    find the groups and teachers by the inexact name
+
+Run "ulstu-schedule <command> -h" to see the flags of the command.
+`
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "ulstu-schedule: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// run executes the command from the arguments and writes its output to w.
+func run(args []string, w io.Writer) error {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(w, usage)
+		return nil
+	}
+
+	switch args[0] {
+	case "group", "teacher", "room":
+		return runSchedule(args[0], args[1:], w)
+	case "groups":
+		return runGroups(args[1:], w)
+	case "teachers":
+		return runTeachers(args[1:], w)
+	case "search":
+		return runSearch(args[1:], w)
+	case "help", "-h", "--help":
+		_, _ = fmt.Fprint(w, usage)
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q\n\n%s", args[0], usage)
+	}
+}
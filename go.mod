@@ -4,14 +4,27 @@ go 1.17
 
 require (
 	github.com/PuerkitoBio/goquery v1.7.1
-	golang.org/x/text v0.3.6
+	github.com/mattn/go-sqlite3 v1.14.16
+	github.com/stretchr/testify v1.8.4
+	golang.org/x/text v0.13.0
+	google.golang.org/grpc v1.56.3
+	google.golang.org/protobuf v1.30.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.13.0 // indirect
+	google.golang.org/genproto v0.0.0-20230410155749-daa745c078e1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
 require (
 	github.com/andybalholm/cascadia v1.2.0 // indirect
 	github.com/fogleman/gg v1.3.0
 	golang.org/x/image v0.0.0-20210628002857-a66eb6448b8d // indirect
-	golang.org/x/net v0.0.0-20210614182718-04defd469f4e // indirect
+	golang.org/x/net v0.17.0 // indirect
 )
 
 require (
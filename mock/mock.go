@@ -4,6 +4,7 @@ import (
 	"github.com/mailru/easyjson"
 	"github.com/stretchr/testify/assert"
 	"github.com/ulstu-schedule/parser/types"
+	"os"
 	"testing"
 )
 
@@ -51,3 +52,21 @@ func TestRoomSchedule(t *testing.T) *types.Schedule {
 	}
 	return schedule
 }
+
+// ChdirTemp changes the working directory to the empty temporary one until the end of the test and returns it.
+func ChdirTemp(t *testing.T) string {
+	t.Helper()
+
+	prevDir, err := os.Getwd()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	dir := t.TempDir()
+	if !assert.NoError(t, os.Chdir(dir)) {
+		t.FailNow()
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(prevDir)
+	})
+	return dir
+}
@@ -0,0 +1,228 @@
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ulstu-schedule/parser/types"
+)
+
+// conflictSlot is the time slot of the school week in which the conflicts are searched.
+type conflictSlot struct {
+	weekNumber int
+	weekDayNum int
+	lessonNum  int
+}
+
+// conflictLesson identifies the lesson that several groups can attend together, e.g. the lecture for the stream.
+type conflictLesson struct {
+	name        string
+	lessonType  types.LessonType
+	teacherName string
+}
+
+// FindConflicts returns the conflicts in the group schedules of the snapshot: the teachers that have lessons in
+// different rooms at the same time, the rooms booked by unrelated lessons at the same time and the groups that have
+// different lessons at the same time, unless the lessons are for different subgroups. The schedules of the teachers
+// and rooms are built from the group schedules, so they are not checked separately. The lessons taught by the whole
+// department are not checked for the teacher conflicts, and the distance learning rooms are not considered booked.
+// The lessons with no room are not counted as being in another room of the teacher. The conflicts are sorted by
+// their time.
+func FindConflicts(snapshot *types.Snapshot) []types.Conflict {
+	conflicts := make([]types.Conflict, 0)
+	teacherSubLessons := map[conflictSlot]map[string][]types.SubLesson{}
+	roomSubLessons := map[conflictSlot]map[string][]types.SubLesson{}
+	slotDates := map[conflictSlot]time.Time{}
+
+	for entity, groupSchedule := range snapshot.Schedules {
+		if entity.Type != types.Group {
+			continue
+		}
+
+		for _, week := range groupSchedule.Weeks {
+			for dayIdx, day := range week.Days {
+				for lessonIdx, lesson := range day.Lessons {
+					if len(lesson.SubLessons) == 0 {
+						continue
+					}
+
+					slot := conflictSlot{weekNumber: week.Number, weekDayNum: dayIdx, lessonNum: lessonIdx}
+					slotDates[slot] = getDiffDate(week.DateStart, dayIdx)
+
+					if hasGroupConflict(lesson.SubLessons) {
+						conflicts = append(conflicts, newConflict(types.GroupConflict, entity, slot, slotDates[slot],
+							lesson.SubLessons))
+					}
+
+					for _, subLesson := range lesson.SubLessons {
+						if subLesson.Teacher != "" && !findPseudoTeacher.MatchString(subLesson.Teacher) {
+							addConflictSubLesson(teacherSubLessons, slot, subLesson.Teacher, subLesson)
+						}
+						if subLesson.Room != "" && !isDistanceRoom(subLesson.Room) {
+							addConflictSubLesson(roomSubLessons, slot, subLesson.Room, subLesson)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for slot, teachers := range teacherSubLessons {
+		for teacher, subLessons := range teachers {
+			if countDistinct(subLessons, getConflictRoom) > 1 {
+				conflicts = append(conflicts, newConflict(types.TeacherConflict,
+					types.Entity{Type: types.Teacher, Name: teacher}, slot, slotDates[slot], subLessons))
+			}
+		}
+	}
+	for slot, rooms := range roomSubLessons {
+		for room, subLessons := range rooms {
+			if countDistinct(subLessons, getConflictLesson) > 1 {
+				conflicts = append(conflicts, newConflict(types.RoomConflict, types.Entity{Type: types.Room, Name: room},
+					slot, slotDates[slot], subLessons))
+			}
+		}
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		a, b := conflicts[i], conflicts[j]
+		switch {
+		case a.WeekNumber != b.WeekNumber:
+			return a.WeekNumber < b.WeekNumber
+		case a.WeekDayNum != b.WeekDayNum:
+			return a.WeekDayNum < b.WeekDayNum
+		case a.LessonNum != b.LessonNum:
+			return a.LessonNum < b.LessonNum
+		case a.Kind != b.Kind:
+			return a.Kind < b.Kind
+		default:
+			return a.Entity.Name < b.Entity.Name
+		}
+	})
+	return conflicts
+}
+
+// ConvertConflictToText converts the information that types.Conflict contains into text. The SubLessons are
+// displayed as in the schedule of the entity which has the conflict.
+func ConvertConflictToText(conflict types.Conflict) string {
+	position := getChangePositionStr(conflict.WeekDayNum, conflict.LessonNum, conflict.Date)
+	position = fmt.Sprintf("%d-ая учебная неделя, %s", conflict.WeekNumber, position)
+
+	subLessonStrs := make([]string, 0, len(conflict.SubLessons))
+	for _, subLesson := range conflict.SubLessons {
+		subLessonStrs = append(subLessonStrs, getChangeSubLessonStr(subLesson, conflict.Entity.Type))
+	}
+	subLessonsStr := strings.Join(subLessonStrs, "; ")
+
+	switch conflict.Kind {
+	case types.TeacherConflict:
+		return fmt.Sprintf("Преподаватель %s в нескольких аудиториях: %s: %s", conflict.Entity.Name, position,
+			subLessonsStr)
+	case types.RoomConflict:
+		return fmt.Sprintf("Аудитория %s занята разными парами: %s: %s", conflict.Entity.Name, position,
+			subLessonsStr)
+	case types.GroupConflict:
+		return fmt.Sprintf("У группы %s пересекаются пары: %s: %s", conflict.Entity.Name, position, subLessonsStr)
+	default:
+		return ""
+	}
+}
+
+// ConvertConflictsToText converts the conflicts into text, one conflict per line.
+func ConvertConflictsToText(conflicts []types.Conflict) string {
+	if len(conflicts) == 0 {
+		return "Конфликтов в расписании не найдено"
+	}
+
+	lines := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		lines = append(lines, ConvertConflictToText(conflict))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hasGroupConflict returns true if the SubLessons of the group lesson contain different lessons for the whole group
+// or for the same subgroup, otherwise - false.
+func hasGroupConflict(subLessons []types.SubLesson) bool {
+	for i := range subLessons {
+		for j := i + 1; j < len(subLessons); j++ {
+			a, b := subLessons[i], subLessons[j]
+			if strings.TrimSpace(a.Name) == strings.TrimSpace(b.Name) && a.Type == b.Type {
+				continue
+			}
+
+			aSubGroup, bSubGroup := a.SubGroupNumber(), b.SubGroupNumber()
+			if aSubGroup == 0 || bSubGroup == 0 || aSubGroup == bSubGroup {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// addConflictSubLesson adds the SubLesson of the teacher or room to the SubLessons of the slot.
+func addConflictSubLesson(subLessons map[conflictSlot]map[string][]types.SubLesson, slot conflictSlot, name string,
+	subLesson types.SubLesson) {
+	slotSubLessons, ok := subLessons[slot]
+	if !ok {
+		slotSubLessons = map[string][]types.SubLesson{}
+		subLessons[slot] = slotSubLessons
+	}
+	slotSubLessons[name] = append(slotSubLessons[name], subLesson)
+}
+
+// getConflictLesson returns the lesson which the SubLesson belongs to.
+func getConflictLesson(subLesson types.SubLesson) interface{} {
+	return conflictLesson{name: strings.TrimSpace(subLesson.Name), lessonType: subLesson.Type,
+		teacherName: subLesson.Teacher}
+}
+
+// getConflictRoom returns the room of the SubLesson, or nil if the room is unknown.
+func getConflictRoom(subLesson types.SubLesson) interface{} {
+	if subLesson.Room == "" {
+		return nil
+	}
+	return subLesson.Room
+}
+
+// countDistinct returns the number of the distinct keys of the SubLessons. The nil keys are not counted.
+func countDistinct(subLessons []types.SubLesson, getKey func(sl types.SubLesson) interface{}) int {
+	keys := map[interface{}]bool{}
+	for _, subLesson := range subLessons {
+		if key := getKey(subLesson); key != nil {
+			keys[key] = true
+		}
+	}
+	return len(keys)
+}
+
+// newConflict returns the conflict with the copy of the SubLessons sorted by the groups and rooms, so the order does
+// not depend on the order of the schedules in the snapshot.
+func newConflict(kind types.ConflictKind, entity types.Entity, slot conflictSlot, date time.Time,
+	subLessons []types.SubLesson) types.Conflict {
+	sorted := append([]types.SubLesson(nil), subLessons...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Group != sorted[j].Group {
+			return sorted[i].Group < sorted[j].Group
+		}
+		return sorted[i].Room < sorted[j].Room
+	})
+
+	return types.Conflict{
+		Kind:       kind,
+		Entity:     entity,
+		WeekNumber: slot.weekNumber,
+		WeekDayNum: slot.weekDayNum,
+		LessonNum:  slot.lessonNum,
+		Date:       date,
+		SubLessons: sorted,
+	}
+}
+
+// isDistanceRoom returns true if the room is used for the distance learning, e.g. "5-ДОТ", so several lessons can
+// take place in it at the same time, otherwise - false.
+func isDistanceRoom(room string) bool {
+	return strings.Contains(room, "ДОТ")
+}
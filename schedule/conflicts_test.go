@@ -0,0 +1,162 @@
+package schedule
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/mock"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+func newConflictsTestSchedule(lessons map[int][]types.SubLesson) *types.Schedule {
+	week := types.Week{Number: 11, DateStart: time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)}
+	for dayIdx := range week.Days {
+		week.Days[dayIdx].Lessons = make([]types.Lesson, 8)
+	}
+	for lessonIdx, subLessons := range lessons {
+		week.Days[1].Lessons[lessonIdx].SubLessons = subLessons
+	}
+	return &types.Schedule{Weeks: []types.Week{week}}
+}
+
+func TestFindConflicts(t *testing.T) {
+	lecture := types.SubLesson{Name: "Философия", Type: types.Lecture, Teacher: "Иванов И И", Room: "6-401"}
+
+	firstGroupLecture, secondGroupLecture := lecture, lecture
+	firstGroupLecture.Group, secondGroupLecture.Group = "ПИбд-11", "ПИбд-12"
+
+	firstSubGroup := types.SubLesson{Name: "Физика", Type: types.Laboratory, Teacher: "Петров П П", Room: "3-101",
+		SubGroup: "1 п/г", Group: "ПИбд-11"}
+	secondSubGroup := types.SubLesson{Name: "Химия", Type: types.Laboratory, Teacher: "Сидоров С С", Room: "3-102",
+		SubGroup: "2 п/г", Group: "ПИбд-11"}
+	teacherElsewhere := types.SubLesson{Name: "Физика", Type: types.Practice, Teacher: "Петров П П", Room: "3-103",
+		Group: "ПИбд-12"}
+	roomTaken := types.SubLesson{Name: "Экономика", Type: types.Practice, Teacher: "Козлов К К", Room: "3-102",
+		Group: "ПИбд-12"}
+	overlapping := types.SubLesson{Name: "История", Type: types.Practice, Teacher: "Орлов О О", Room: "6-210",
+		Group: "ПИбд-12"}
+	pseudoTeacher := types.SubLesson{Name: "Элективные курсы по физической культуре", Type: types.Practice,
+		Teacher: "Преподaватели кафедры", Room: "6-401", Group: "ПИбд-12"}
+	distance := types.SubLesson{Name: "Математика", Type: types.Lecture, Teacher: "Волков В В", Room: "5-ДОТ",
+		Group: "ПИбд-11"}
+	otherDistance := types.SubLesson{Name: "Информатика", Type: types.Lecture, Teacher: "Зайцев З З", Room: "5-ДОТ",
+		Group: "ПИбд-12"}
+
+	// the room is booked by the lesson of the whole department and by the lesson with no teacher as well
+	pseudoTeacherRoomTaken := types.SubLesson{Name: "Экономика", Type: types.Practice, Teacher: "Козлов К К",
+		Room: "6-401", Group: "ПИбд-11"}
+	noTeacher := types.SubLesson{Name: "Учебная практика", Type: types.Practice, Room: "6-210", Group: "ПИбд-11"}
+	noTeacherRoomTaken := types.SubLesson{Name: "История", Type: types.Practice, Teacher: "Орлов О О", Room: "6-210",
+		Group: "ПИбд-12"}
+	// the lesson with no room is not in another room of the teacher
+	noRoom := types.SubLesson{Name: "История", Type: types.Lecture, Teacher: "Орлов О О", Group: "ПИбд-11"}
+	withRoom := types.SubLesson{Name: "История", Type: types.Lecture, Teacher: "Орлов О О", Room: "6-210",
+		Group: "ПИбд-12"}
+
+	snapshot := types.NewSnapshot()
+	snapshot.Add(types.Entity{Type: types.Group, Name: "ПИбд-11"}, newConflictsTestSchedule(map[int][]types.SubLesson{
+		0: {firstGroupLecture},
+		1: {firstSubGroup, secondSubGroup},
+		2: {distance},
+		3: {pseudoTeacherRoomTaken},
+		4: {noTeacher},
+		5: {noRoom},
+	}), time.Now())
+	snapshot.Add(types.Entity{Type: types.Group, Name: "ПИбд-12"}, newConflictsTestSchedule(map[int][]types.SubLesson{
+		0: {secondGroupLecture},
+		1: {teacherElsewhere, roomTaken, overlapping},
+		2: {otherDistance},
+		3: {pseudoTeacher},
+		4: {noTeacherRoomTaken},
+		5: {withRoom},
+	}), time.Now())
+
+	conflicts := FindConflicts(snapshot)
+	if !assert.Len(t, conflicts, 5) {
+		return
+	}
+
+	// the common lecture of the stream and the lessons of different subgroups are not conflicts
+	assert.EqualValues(t, types.TeacherConflict, conflicts[0].Kind)
+	assert.EqualValues(t, types.Entity{Type: types.Teacher, Name: "Петров П П"}, conflicts[0].Entity)
+	assert.EqualValues(t, []types.SubLesson{firstSubGroup, teacherElsewhere}, conflicts[0].SubLessons)
+
+	assert.EqualValues(t, types.RoomConflict, conflicts[1].Kind)
+	assert.EqualValues(t, types.Entity{Type: types.Room, Name: "3-102"}, conflicts[1].Entity)
+	assert.EqualValues(t, []types.SubLesson{secondSubGroup, roomTaken}, conflicts[1].SubLessons)
+
+	assert.EqualValues(t, types.GroupConflict, conflicts[2].Kind)
+	assert.EqualValues(t, types.Entity{Type: types.Group, Name: "ПИбд-12"}, conflicts[2].Entity)
+	assert.Len(t, conflicts[2].SubLessons, 3)
+
+	for _, conflict := range conflicts[:3] {
+		assert.EqualValues(t, 11, conflict.WeekNumber)
+		assert.EqualValues(t, 1, conflict.WeekDayNum)
+		assert.EqualValues(t, 1, conflict.LessonNum)
+		assert.EqualValues(t, time.Date(2024, 4, 16, 0, 0, 0, 0, time.UTC), conflict.Date)
+	}
+
+	assert.EqualValues(t, types.RoomConflict, conflicts[3].Kind)
+	assert.EqualValues(t, 3, conflicts[3].LessonNum)
+	assert.EqualValues(t, types.Entity{Type: types.Room, Name: "6-401"}, conflicts[3].Entity)
+	assert.EqualValues(t, []types.SubLesson{pseudoTeacherRoomTaken, pseudoTeacher}, conflicts[3].SubLessons)
+
+	assert.EqualValues(t, types.RoomConflict, conflicts[4].Kind)
+	assert.EqualValues(t, 4, conflicts[4].LessonNum)
+	assert.EqualValues(t, types.Entity{Type: types.Room, Name: "6-210"}, conflicts[4].Entity)
+	assert.EqualValues(t, []types.SubLesson{noTeacher, noTeacherRoomTaken}, conflicts[4].SubLessons)
+}
+
+func TestFindConflictsMock(t *testing.T) {
+	snapshot := types.NewSnapshot()
+	snapshot.Add(types.Entity{Type: types.Group, Name: "АТсд-21"}, mock.TestGroupSchedule(t), time.Now())
+	// the teacher schedules are not checked, they are built from the group schedules
+	snapshot.Add(types.Entity{Type: types.Teacher, Name: "Зенкина С М"}, mock.TestTeacherSchedule(t), time.Now())
+
+	// the subgroups of the published schedule have their lessons at different time
+	assert.Empty(t, FindConflicts(snapshot))
+}
+
+func TestConvertConflictsToText(t *testing.T) {
+	assert.EqualValues(t, "Конфликтов в расписании не найдено", ConvertConflictsToText(nil))
+
+	conflicts := []types.Conflict{
+		{
+			Kind:       types.TeacherConflict,
+			Entity:     types.Entity{Type: types.Teacher, Name: "Петров П П"},
+			WeekNumber: 11,
+			WeekDayNum: 1,
+			LessonNum:  1,
+			Date:       time.Date(2024, 4, 16, 0, 0, 0, 0, time.UTC),
+			SubLessons: []types.SubLesson{
+				{Name: "Физика", Type: types.Laboratory, Group: "ПИбд-11", Room: "3-101"},
+				{Name: "Физика", Type: types.Practice, Group: "ПИбд-12", Room: "3-103"},
+			},
+		},
+		{
+			Kind:       types.RoomConflict,
+			Entity:     types.Entity{Type: types.Room, Name: "3-102"},
+			WeekNumber: 11,
+			WeekDayNum: 0,
+			LessonNum:  0,
+			SubLessons: []types.SubLesson{
+				{Name: "Химия", Type: types.Laboratory, Group: "ПИбд-11", Teacher: "Сидоров С С"},
+				{Name: "Экономика", Type: types.Practice, Group: "ПИбд-12", Teacher: "Козлов К К"},
+			},
+		},
+	}
+
+	lines := strings.Split(ConvertConflictsToText(conflicts), "\n")
+	if !assert.Len(t, lines, 2) {
+		return
+	}
+	assert.EqualValues(t, "Преподаватель Петров П П в нескольких аудиториях: 11-ая учебная неделя, "+
+		"Вторник (16.04), 2-ая пара (10:00-11:20): "+
+		"Лаб. Физика ПИбд-11, аудитория 3-101; Пр. Физика ПИбд-12, аудитория 3-103", lines[0])
+	assert.EqualValues(t, "Аудитория 3-102 занята разными парами: 11-ая учебная неделя, "+
+		"Понедельник, 1-ая пара (08:30-09:50): "+
+		"Лаб., Химия, группа ПИбд-11, преподаватель Сидоров С С; Пр., Экономика, группа ПИбд-12, "+
+		"преподаватель Козлов К К", lines[1])
+}
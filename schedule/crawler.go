@@ -0,0 +1,172 @@
+package schedule
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ulstu-schedule/parser/types"
+)
+
+const defaultCrawlerWorkers = 4
+
+// CrawlProgress represents the progress of the crawling after the schedule of Entity is fetched.
+type CrawlProgress struct {
+	Done   int
+	Total  int
+	Entity types.Entity
+	Err    error
+}
+
+// Crawler downloads the schedules of all the groups and teachers from UlSTU site. The lists of groups and teachers
+// are loaded once, then the schedule pages are downloaded by a bounded number of workers.
+type Crawler struct {
+	// Workers is the number of the schedules downloaded at the same time
+	Workers int
+	// OnProgress is called after each schedule is fetched, the calls are not concurrent
+	OnProgress func(progress CrawlProgress)
+
+	listGroups   func() ([]types.GroupInfo, error)
+	listTeachers func() ([]types.TeacherInfo, error)
+	fetch        func(name string, url string, typeSchedule types.ScheduleType) (*types.Schedule, error)
+}
+
+// crawlJob is the schedule page to download.
+type crawlJob struct {
+	entity types.Entity
+	url    string
+}
+
+// crawlResult is the downloaded schedule.
+type crawlResult struct {
+	entity    types.Entity
+	schedule  *types.Schedule
+	fetchedAt time.Time
+	err       error
+}
+
+// NewCrawler returns *Crawler that downloads the schedules from UlSTU site with the number of workers.
+func NewCrawler(workers int) *Crawler {
+	return &Crawler{
+		Workers:      workers,
+		listGroups:   GetGroupsInfo,
+		listTeachers: GetTeachersInfo,
+		fetch: func(name string, url string, typeSchedule types.ScheduleType) (*types.Schedule, error) {
+			return getFullSchedule(name, url, typeSchedule, nil)
+		},
+	}
+}
+
+// Crawl downloads the schedules of all the groups and teachers. The schedules that failed to download (including
+// the unpublished ones) are returned as *types.EntityError, the error is returned only if the lists of groups and
+// teachers cannot be loaded or ctx is done. Crawl returns as soon as ctx is done, the downloads in progress are not
+// waited for and their results are discarded.
+func (c *Crawler) Crawl(ctx context.Context) (*types.Snapshot, []*types.EntityError, error) {
+	snapshot := types.NewSnapshot()
+	snapshot.StartedAt = time.Now()
+
+	jobs, err := c.getJobs()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	workers := c.Workers
+	if workers <= 0 {
+		workers = defaultCrawlerWorkers
+	}
+
+	jobsCh := make(chan crawlJob)
+	resultsCh := make(chan crawlResult)
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobsCh {
+				schedule, err := c.fetch(job.entity.Name, job.url, job.entity.Type)
+				select {
+				case resultsCh <- crawlResult{entity: job.entity, schedule: schedule, fetchedAt: time.Now(), err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobsCh)
+		for _, job := range jobs {
+			select {
+			case jobsCh <- job:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resultsCh)
+	}()
+
+	entityErrs := make([]*types.EntityError, 0)
+	done := 0
+	for {
+		var (
+			result crawlResult
+			ok     bool
+		)
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case result, ok = <-resultsCh:
+		}
+		if !ok {
+			break
+		}
+
+		done++
+		if result.err != nil {
+			entityErrs = append(entityErrs, &types.EntityError{Entity: result.entity, Err: result.err})
+		} else {
+			snapshot.Add(result.entity, result.schedule, result.fetchedAt)
+		}
+
+		if c.OnProgress != nil {
+			c.OnProgress(CrawlProgress{Done: done, Total: len(jobs), Entity: result.entity, Err: result.err})
+		}
+	}
+
+	if err = ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	snapshot.FinishedAt = time.Now()
+	return snapshot, entityErrs, nil
+}
+
+// getJobs returns the schedule pages of all the groups and teachers.
+func (c *Crawler) getJobs() ([]crawlJob, error) {
+	groups, err := c.listGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	teachers, err := c.listTeachers()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]crawlJob, 0, len(groups)+len(teachers))
+	for _, group := range groups {
+		jobs = append(jobs, crawlJob{entity: types.Entity{Type: types.Group, Name: group.Name}, url: group.URL})
+	}
+	for _, teacher := range teachers {
+		if teacher.URL == "" {
+			continue
+		}
+		jobs = append(jobs, crawlJob{entity: types.Entity{Type: types.Teacher, Name: teacher.Name}, url: teacher.URL})
+	}
+	return jobs, nil
+}
@@ -0,0 +1,94 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+func newTestCrawler(workers int, fetch func(string, string, types.ScheduleType) (*types.Schedule, error)) *Crawler {
+	c := NewCrawler(workers)
+	c.listGroups = func() ([]types.GroupInfo, error) {
+		return []types.GroupInfo{{Name: "АТсд-21", URL: "group1"}, {Name: "ПИбд-11", URL: "group2"}}, nil
+	}
+	c.listTeachers = func() ([]types.TeacherInfo, error) {
+		return []types.TeacherInfo{{Name: "Зенкина С М", URL: "teacher1"}, {Name: "Вакансия", URL: ""}}, nil
+	}
+	c.fetch = fetch
+	return c
+}
+
+func TestCrawlerCrawl(t *testing.T) {
+	t.Run("all schedules", func(t *testing.T) {
+		var fetchesNum int32
+		c := newTestCrawler(2, func(name string, url string, _ types.ScheduleType) (*types.Schedule, error) {
+			atomic.AddInt32(&fetchesNum, 1)
+			if url == "group2" {
+				return nil, &types.UnavailableScheduleError{Name: name, Scope: types.FullScope}
+			}
+			return &types.Schedule{Weeks: []types.Week{{Number: 11}}}, nil
+		})
+
+		progress := make([]CrawlProgress, 0)
+		c.OnProgress = func(p CrawlProgress) {
+			progress = append(progress, p)
+		}
+
+		snapshot, entityErrs, err := c.Crawl(context.Background())
+		assert.NoError(t, err)
+		assert.EqualValues(t, 3, fetchesNum)
+
+		assert.Len(t, snapshot.Schedules, 2)
+		assert.Contains(t, snapshot.Schedules, types.Entity{Type: types.Group, Name: "АТсд-21"})
+		assert.Contains(t, snapshot.Schedules, types.Entity{Type: types.Teacher, Name: "Зенкина С М"})
+		assert.Len(t, snapshot.FetchedAt, 2)
+		assert.False(t, snapshot.FinishedAt.Before(snapshot.StartedAt))
+
+		assert.Len(t, entityErrs, 1)
+		assert.EqualValues(t, types.Entity{Type: types.Group, Name: "ПИбд-11"}, entityErrs[0].Entity)
+		assert.True(t, errors.Is(entityErrs[0], types.ErrNotPublished))
+
+		assert.Len(t, progress, 3)
+		assert.EqualValues(t, 3, progress[2].Done)
+		assert.EqualValues(t, 3, progress[2].Total)
+	})
+	t.Run("list error", func(t *testing.T) {
+		c := newTestCrawler(2, nil)
+		listErr := &types.StatusCodeError{StatusCode: 503, StatusText: "Service Unavailable"}
+		c.listTeachers = func() ([]types.TeacherInfo, error) {
+			return nil, listErr
+		}
+
+		_, _, err := c.Crawl(context.Background())
+		assert.ErrorIs(t, err, listErr)
+	})
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		c := newTestCrawler(1, func(string, string, types.ScheduleType) (*types.Schedule, error) {
+			cancel()
+			return &types.Schedule{}, nil
+		})
+
+		_, _, err := c.Crawl(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+	t.Run("cancelled during download", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		release := make(chan struct{})
+		defer close(release)
+
+		// the download ignores ctx, as the requests to the site do
+		c := newTestCrawler(2, func(string, string, types.ScheduleType) (*types.Schedule, error) {
+			cancel()
+			<-release
+			return &types.Schedule{}, nil
+		})
+
+		_, _, err := c.Crawl(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
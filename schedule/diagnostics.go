@@ -0,0 +1,34 @@
+package schedule
+
+import (
+	"github.com/PuerkitoBio/goquery"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+// warnFunc reports the problem found in the schedule table cell.
+type warnFunc func(kind types.ParseWarningKind, fragment string)
+
+// diagnostics collects the warnings found while parsing the schedule. A nil *diagnostics discards the warnings.
+type diagnostics struct {
+	warnings []types.ParseWarning
+}
+
+// cellWarner returns warnFunc that adds the warnings with the coordinates and the HTML of the table cell.
+func (d *diagnostics) cellWarner(week *types.Week, weekIdx, weekDayNum, lessonNum int, cellS *goquery.Selection) warnFunc {
+	if d == nil {
+		return func(types.ParseWarningKind, string) {}
+	}
+
+	return func(kind types.ParseWarningKind, fragment string) {
+		cellHTML, _ := cellS.Html()
+		d.warnings = append(d.warnings, types.ParseWarning{
+			Kind:       kind,
+			WeekNumber: week.Number,
+			WeekIdx:    weekIdx,
+			WeekDayNum: weekDayNum,
+			LessonNum:  lessonNum,
+			Fragment:   fragment,
+			CellHTML:   cellHTML,
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package schedule
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+func TestParseFullScheduleDiagnostics(t *testing.T) {
+	t.Run("group schedule", func(t *testing.T) {
+		lessons := map[string]string{
+			"0:1": "лек.Философия <br>Розанов Ф И 6-419 <br>",
+			"1:2": "сем.Философия <br>Розанов Ф И 6-419 <br>",
+			"2:3": "лек.Философия <br>",
+		}
+		doc := testScheduleDoc(t, testScheduleTableHTML(11, lessons, false))
+
+		diag := &diagnostics{}
+		_, err := parseFullSchedule(doc, "АТсд-21", types.Group, diag)
+		assert.NoError(t, err)
+
+		assert.Len(t, diag.warnings, 2)
+		assert.EqualValues(t, types.UnknownLessonTypeFragment, diag.warnings[0].Kind)
+		assert.EqualValues(t, 1, diag.warnings[0].WeekDayNum)
+		assert.EqualValues(t, 1, diag.warnings[0].LessonNum)
+		assert.EqualValues(t, 11, diag.warnings[0].WeekNumber)
+
+		assert.EqualValues(t, types.UnparsedFragment, diag.warnings[1].Kind)
+		assert.EqualValues(t, 2, diag.warnings[1].WeekDayNum)
+		assert.EqualValues(t, 2, diag.warnings[1].LessonNum)
+		assert.Contains(t, diag.warnings[1].CellHTML, "Философия")
+	})
+	t.Run("teacher schedule", func(t *testing.T) {
+		lessons := map[string]string{
+			"0:1": "ИДбв-11<br>пр.Типографика и шрифтография<br>5-ДОТ",
+			"4:2": "ИДбв-11<br>",
+		}
+		doc := testScheduleDoc(t, testScheduleTableHTML(10, lessons, false))
+
+		diag := &diagnostics{}
+		schedule, err := parseFullSchedule(doc, "Зенкина С М", types.Teacher, diag)
+		assert.NoError(t, err)
+
+		assert.Len(t, schedule.Weeks[0].Days[0].Lessons[0].SubLessons, 1)
+		assert.Len(t, schedule.Weeks[0].Days[4].Lessons[1].SubLessons, 0)
+
+		assert.Len(t, diag.warnings, 1)
+		assert.EqualValues(t, types.IncompleteLessonInfo, diag.warnings[0].Kind)
+	})
+	t.Run("warnings of the row in column order", func(t *testing.T) {
+		lessons := map[string]string{}
+		for lessonNum := 1; lessonNum <= 8; lessonNum++ {
+			lessons[fmt.Sprintf("1:%d", lessonNum)] = "лек.Философия <br>"
+		}
+		doc := testScheduleDoc(t, testScheduleTableHTML(11, lessons, false))
+
+		for i := 0; i < 10; i++ {
+			diag := &diagnostics{}
+			_, err := parseFullSchedule(doc, "АТсд-21", types.Group, diag)
+			assert.NoError(t, err)
+
+			if assert.Len(t, diag.warnings, 8) {
+				for warningIdx, warning := range diag.warnings {
+					assert.EqualValues(t, warningIdx, warning.LessonNum)
+				}
+			}
+		}
+	})
+	t.Run("without diagnostics", func(t *testing.T) {
+		lessons := map[string]string{"2:3": "лек.Философия <br>"}
+		doc := testScheduleDoc(t, testScheduleTableHTML(11, lessons, false))
+
+		_, err := parseFullSchedule(doc, "АТсд-21", types.Group, nil)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,247 @@
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ulstu-schedule/parser/types"
+)
+
+// diffSubLesson is the SubLesson with its position in the week compared by Diff.
+type diffSubLesson struct {
+	weekDayNum int
+	lessonNum  int
+	subLesson  types.SubLesson
+	isMatched  bool
+}
+
+// Diff returns the changes of the SubLessons between the old and the new versions of the schedule. Every week of the
+// new schedule is compared with the week of the old schedule that takes its place in the rotation, so the schedules
+// fetched in different weeks of the semester can be compared. The lessons of the old weeks that take no place of the
+// new weeks, e.g. when only the session week is published, are reported as removed in their old weeks.
+func Diff(old, new *types.Schedule) []types.Change {
+	changes := make([]types.Change, 0)
+	if new == nil {
+		return changes
+	}
+
+	matchedOldWeeks := map[int]bool{}
+	for _, newWeek := range new.Weeks {
+		oldWeek := types.Week{}
+		if old != nil {
+			if oldWeekIdx, ok := old.WeekIdxByNumber(newWeek.Number); ok {
+				oldWeek = old.Weeks[oldWeekIdx]
+				matchedOldWeeks[oldWeekIdx] = true
+			}
+		}
+		changes = append(changes, diffWeeks(oldWeek, newWeek)...)
+	}
+
+	if old != nil {
+		for oldWeekIdx, oldWeek := range old.Weeks {
+			if !matchedOldWeeks[oldWeekIdx] {
+				changes = append(changes, diffWeeks(oldWeek, types.Week{Number: oldWeek.Number})...)
+			}
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.WeekNumber != b.WeekNumber {
+			return a.WeekNumber < b.WeekNumber
+		}
+		if a.WeekDayNum != b.WeekDayNum {
+			return a.WeekDayNum < b.WeekDayNum
+		}
+		if a.LessonNum != b.LessonNum {
+			return a.LessonNum < b.LessonNum
+		}
+		return a.Kind < b.Kind
+	})
+	return changes
+}
+
+// diffWeeks returns the changes of the SubLessons between the old and the new versions of the week.
+func diffWeeks(oldWeek, newWeek types.Week) []types.Change {
+	oldSubLessons, newSubLessons := getDiffSubLessons(oldWeek), getDiffSubLessons(newWeek)
+	changes := make([]types.Change, 0)
+
+	dateStart := newWeek.DateStart
+	if dateStart.IsZero() {
+		dateStart = oldWeek.DateStart
+	}
+	newChange := func(kind types.ChangeKind, pos *diffSubLesson) types.Change {
+		return types.Change{Kind: kind, WeekNumber: newWeek.Number, WeekDayNum: pos.weekDayNum,
+			LessonNum: pos.lessonNum, Date: getDiffDate(dateStart, pos.weekDayNum)}
+	}
+
+	// unchanged SubLessons
+	matchDiffSubLessons(oldSubLessons, newSubLessons, func(oldSL, newSL *diffSubLesson) bool {
+		return isSamePosition(oldSL, newSL) && oldSL.subLesson == newSL.subLesson
+	}, nil)
+
+	// the same SubLessons in the same slot with another room or teacher
+	matchDiffSubLessons(oldSubLessons, newSubLessons, func(oldSL, newSL *diffSubLesson) bool {
+		return isSamePosition(oldSL, newSL) && isSameSubLesson(oldSL.subLesson, newSL.subLesson) &&
+			(oldSL.subLesson.Room != newSL.subLesson.Room || oldSL.subLesson.Teacher != newSL.subLesson.Teacher)
+	}, func(oldSL, newSL *diffSubLesson) {
+		if oldSL.subLesson.Room != newSL.subLesson.Room {
+			changes = append(changes, newSubLessonChange(newChange(types.RoomChanged, newSL), oldSL, newSL))
+		}
+		if oldSL.subLesson.Teacher != newSL.subLesson.Teacher {
+			changes = append(changes, newSubLessonChange(newChange(types.TeacherChanged, newSL), oldSL, newSL))
+		}
+	})
+
+	// the same SubLessons moved to another slot of the same day, then to another day
+	onMoved := func(oldSL, newSL *diffSubLesson) {
+		change := newSubLessonChange(newChange(types.Moved, newSL), oldSL, newSL)
+		change.PrevWeekDayNum, change.PrevLessonNum = oldSL.weekDayNum, oldSL.lessonNum
+		change.PrevDate = getDiffDate(dateStart, oldSL.weekDayNum)
+		changes = append(changes, change)
+	}
+	matchDiffSubLessons(oldSubLessons, newSubLessons, func(oldSL, newSL *diffSubLesson) bool {
+		return oldSL.weekDayNum == newSL.weekDayNum && isSameSubLesson(oldSL.subLesson, newSL.subLesson)
+	}, onMoved)
+	matchDiffSubLessons(oldSubLessons, newSubLessons, func(oldSL, newSL *diffSubLesson) bool {
+		return isSameSubLesson(oldSL.subLesson, newSL.subLesson)
+	}, onMoved)
+
+	for _, oldSL := range oldSubLessons {
+		if !oldSL.isMatched {
+			change := newChange(types.Removed, oldSL)
+			change.Before = &oldSL.subLesson
+			changes = append(changes, change)
+		}
+	}
+	for _, newSL := range newSubLessons {
+		if !newSL.isMatched {
+			change := newChange(types.Added, newSL)
+			change.After = &newSL.subLesson
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+// getDiffSubLessons returns all the SubLessons of the week with their positions.
+func getDiffSubLessons(week types.Week) []*diffSubLesson {
+	subLessons := make([]*diffSubLesson, 0)
+	for weekDayNum, day := range week.Days {
+		for lessonNum, lesson := range day.Lessons {
+			for _, subLesson := range lesson.SubLessons {
+				subLessons = append(subLessons, &diffSubLesson{weekDayNum: weekDayNum, lessonNum: lessonNum,
+					subLesson: subLesson})
+			}
+		}
+	}
+	return subLessons
+}
+
+// matchDiffSubLessons marks as matched the pairs of the old and new SubLessons for which isMatch returns true and
+// calls onMatch for each pair if it is not nil.
+func matchDiffSubLessons(oldSubLessons, newSubLessons []*diffSubLesson, isMatch func(oldSL, newSL *diffSubLesson) bool,
+	onMatch func(oldSL, newSL *diffSubLesson)) {
+	for _, oldSL := range oldSubLessons {
+		if oldSL.isMatched {
+			continue
+		}
+
+		for _, newSL := range newSubLessons {
+			if newSL.isMatched || !isMatch(oldSL, newSL) {
+				continue
+			}
+
+			oldSL.isMatched, newSL.isMatched = true, true
+			if onMatch != nil {
+				onMatch(oldSL, newSL)
+			}
+			break
+		}
+	}
+}
+
+// isSamePosition returns true if the SubLessons are in the same time slot of the same day, otherwise - false.
+func isSamePosition(a, b *diffSubLesson) bool {
+	return a.weekDayNum == b.weekDayNum && a.lessonNum == b.lessonNum
+}
+
+// isSameSubLesson returns true if the SubLessons are the same lesson of the same group, regardless of the time, room
+// and teacher, otherwise - false.
+func isSameSubLesson(a, b types.SubLesson) bool {
+	return strings.TrimSpace(a.Name) == strings.TrimSpace(b.Name) && a.Type == b.Type && a.Group == b.Group &&
+		a.SubGroup == b.SubGroup
+}
+
+// newSubLessonChange returns the change with the old and new versions of the SubLesson.
+func newSubLessonChange(change types.Change, oldSL, newSL *diffSubLesson) types.Change {
+	before, after := oldSL.subLesson, newSL.subLesson
+	change.Before, change.After = &before, &after
+	return change
+}
+
+// getDiffDate returns the date of the day of the week, or zero time if the date of the week start is unknown.
+func getDiffDate(dateStart time.Time, weekDayNum int) time.Time {
+	if dateStart.IsZero() {
+		return time.Time{}
+	}
+	return dateStart.AddDate(0, 0, weekDayNum)
+}
+
+// ConvertChangeToText converts the information that types.Change contains into text. The SubLessons are displayed as
+// in the schedule of typeSchedule.
+func ConvertChangeToText(change types.Change, typeSchedule types.ScheduleType) string {
+	position := getChangePositionStr(change.WeekDayNum, change.LessonNum, change.Date)
+
+	switch change.Kind {
+	case types.Added:
+		return fmt.Sprintf("Добавлена пара: %s: %s", position, getChangeSubLessonStr(*change.After, typeSchedule))
+	case types.Removed:
+		return fmt.Sprintf("Отменена пара: %s: %s", position, getChangeSubLessonStr(*change.Before, typeSchedule))
+	case types.Moved:
+		prevPosition := getChangePositionStr(change.PrevWeekDayNum, change.PrevLessonNum, change.PrevDate)
+		return fmt.Sprintf("Пара перенесена с %s на %s: %s", prevPosition, position,
+			getChangeSubLessonStr(*change.After, typeSchedule))
+	case types.RoomChanged:
+		return fmt.Sprintf("Изменена аудитория: %s: %s %s: %s → %s", position, change.After.Type,
+			strings.TrimSpace(change.After.Name), change.Before.Room, change.After.Room)
+	case types.TeacherChanged:
+		return fmt.Sprintf("Изменён преподаватель: %s: %s %s: %s → %s", position, change.After.Type,
+			strings.TrimSpace(change.After.Name), change.Before.Teacher, change.After.Teacher)
+	default:
+		return ""
+	}
+}
+
+// getChangePositionStr returns a string representation of the position of the change, e.g.
+// "Понедельник (15.04), 2-ая пара (10:00-11:20)". The time is omitted if types.DefaultTimeTable has no such slot.
+func getChangePositionStr(weekDayNum int, lessonNum int, date time.Time) string {
+	weekDay := ""
+	if weekDayNum >= 0 && weekDayNum < len(weekDays) {
+		weekDay = weekDays[weekDayNum]
+	}
+	if !date.IsZero() {
+		weekDay = fmt.Sprintf("%s (%s)", weekDay, date.Format("02.01"))
+	}
+	position := fmt.Sprintf("%s, %d-ая пара", weekDay, lessonNum+1)
+	if lessonTime := types.Duration(lessonNum).StringIn(types.DefaultTimeTable); lessonTime != "" {
+		position = fmt.Sprintf("%s (%s)", position, lessonTime)
+	}
+	return position
+}
+
+// getChangeSubLessonStr returns a string representation of the SubLesson based on the structure of the lesson
+// display for typeSchedule.
+func getChangeSubLessonStr(subLesson types.SubLesson, typeSchedule types.ScheduleType) string {
+	switch typeSchedule {
+	case types.Teacher:
+		return subLesson.StringTeacherSubLesson()
+	case types.Room:
+		return subLesson.StringRoomSubLesson()
+	default:
+		return subLesson.StringGroupSubLesson()
+	}
+}
@@ -0,0 +1,116 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/mock"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+func TestDiff(t *testing.T) {
+	t.Run("no changes", func(t *testing.T) {
+		assert.Empty(t, Diff(mock.TestGroupSchedule(t), mock.TestGroupSchedule(t)))
+	})
+	t.Run("next rotation", func(t *testing.T) {
+		newSchedule := mock.TestGroupSchedule(t)
+		newSchedule.Weeks[0].Number, newSchedule.Weeks[1].Number = 13, 14
+
+		assert.Empty(t, Diff(mock.TestGroupSchedule(t), newSchedule))
+	})
+	t.Run("room and teacher changed", func(t *testing.T) {
+		newSchedule := mock.TestGroupSchedule(t)
+		newSchedule.Weeks[0].Days[1].Lessons[1].SubLessons[0].Room = "6-402"
+		newSchedule.Weeks[0].Days[1].Lessons[1].SubLessons[0].Teacher = "Зенкина С М"
+
+		changes := Diff(mock.TestGroupSchedule(t), newSchedule)
+		assert.Len(t, changes, 2)
+
+		assert.EqualValues(t, types.RoomChanged, changes[0].Kind)
+		assert.EqualValues(t, 11, changes[0].WeekNumber)
+		assert.EqualValues(t, 1, changes[0].WeekDayNum)
+		assert.EqualValues(t, 1, changes[0].LessonNum)
+		assert.EqualValues(t, time.Date(2024, 4, 16, 0, 0, 0, 0, time.UTC), changes[0].Date)
+		assert.EqualValues(t, "6-401", changes[0].Before.Room)
+		assert.EqualValues(t, "6-402", changes[0].After.Room)
+		assert.EqualValues(t, "Изменена аудитория: Вторник (16.04), 2-ая пара (10:00-11:20): Лек. Компьютерная графика: "+
+			"6-401 → 6-402", ConvertChangeToText(changes[0], types.Group))
+
+		assert.EqualValues(t, types.TeacherChanged, changes[1].Kind)
+		assert.EqualValues(t, "Рандин А В", changes[1].Before.Teacher)
+		assert.EqualValues(t, "Зенкина С М", changes[1].After.Teacher)
+	})
+	t.Run("moved", func(t *testing.T) {
+		newSchedule := mock.TestGroupSchedule(t)
+		thursday := &newSchedule.Weeks[0].Days[3]
+		thursday.Lessons[5].SubLessons, thursday.Lessons[3].SubLessons = thursday.Lessons[3].SubLessons, nil
+
+		changes := Diff(mock.TestGroupSchedule(t), newSchedule)
+		assert.Len(t, changes, 1)
+		assert.EqualValues(t, types.Moved, changes[0].Kind)
+		assert.EqualValues(t, 3, changes[0].PrevLessonNum)
+		assert.EqualValues(t, 5, changes[0].LessonNum)
+		assert.Contains(t, ConvertChangeToText(changes[0], types.Group),
+			"Пара перенесена с Четверг (18.04), 4-ая пара")
+	})
+	t.Run("added and removed", func(t *testing.T) {
+		oldSchedule := mock.TestGroupSchedule(t)
+		newSchedule := mock.TestGroupSchedule(t)
+		newSchedule.Weeks[1].Days[4].Lessons[1].SubLessons = nil
+		newSchedule.Weeks[1].Days[5].Lessons[0].SubLessons = []types.SubLesson{{Name: "Правоведение", Group: "АТсд-21",
+			Teacher: "Розанов Ф И", Room: "6-419", Type: types.Practice}}
+
+		changes := Diff(oldSchedule, newSchedule)
+		assert.Len(t, changes, 2)
+
+		assert.EqualValues(t, types.Removed, changes[0].Kind)
+		assert.EqualValues(t, 12, changes[0].WeekNumber)
+		assert.Nil(t, changes[0].After)
+		assert.Contains(t, ConvertChangeToText(changes[0], types.Group), "Отменена пара: Пятница (26.04)")
+
+		assert.EqualValues(t, types.Added, changes[1].Kind)
+		assert.Nil(t, changes[1].Before)
+		assert.EqualValues(t, "Добавлена пара: Суббота (27.04), 1-ая пара (08:30-09:50): Пр. Правоведение, "+
+			"Розанов Ф И, аудитория 6-419", ConvertChangeToText(changes[1], types.Group))
+		// the SubLesson is displayed as in the schedule of the teacher
+		assert.EqualValues(t, "Добавлена пара: Суббота (27.04), 1-ая пара (08:30-09:50): Пр. Правоведение АТсд-21, "+
+			"аудитория 6-419", ConvertChangeToText(changes[1], types.Teacher))
+	})
+	t.Run("week dropped", func(t *testing.T) {
+		oldSchedule := mock.TestGroupSchedule(t)
+		newSchedule := mock.TestGroupSchedule(t)
+		newSchedule.Weeks = newSchedule.Weeks[:1]
+
+		subLessonsNum := 0
+		for _, day := range oldSchedule.Weeks[1].Days {
+			for _, lesson := range day.Lessons {
+				subLessonsNum += len(lesson.SubLessons)
+			}
+		}
+
+		changes := Diff(oldSchedule, newSchedule)
+		if !assert.Len(t, changes, subLessonsNum) {
+			return
+		}
+		for _, change := range changes {
+			assert.EqualValues(t, types.Removed, change.Kind)
+			assert.EqualValues(t, 12, change.WeekNumber)
+		}
+		assert.Contains(t, ConvertChangeToText(changes[0], types.Group), "Отменена пара: Понедельник (22.04)")
+	})
+	t.Run("no old schedule", func(t *testing.T) {
+		changes := Diff(nil, mock.TestTeacherSchedule(t))
+		assert.NotEmpty(t, changes)
+		for _, change := range changes {
+			assert.EqualValues(t, types.Added, change.Kind)
+		}
+	})
+}
+
+func TestGetChangePositionStr(t *testing.T) {
+	assert.EqualValues(t, "Понедельник (15.04), 2-ая пара (10:00-11:20)",
+		getChangePositionStr(0, 1, time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)))
+	// the time table has no time of the lesson
+	assert.EqualValues(t, "Понедельник, 10-ая пара", getChangePositionStr(0, 9, time.Time{}))
+}
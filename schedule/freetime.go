@@ -0,0 +1,110 @@
+package schedule
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ulstu-schedule/parser/types"
+)
+
+// FindCommonFreeSlots returns the time slots of types.DefaultTimeTable from the day of from to the day of to
+// inclusive, in which none of the schedules has lessons, in chronological order. Sundays are skipped. The weeks that
+// are not published are resolved by the rotation, and the participant with no lessons in the resolved week is free on
+// all its days. The dates of the slots are in the location of from. Returns types.UnavailableScheduleError if any of
+// the schedules has no weeks, because it is unknown whether the participant is free.
+func FindCommonFreeSlots(schedules []*types.Schedule, from, to time.Time) ([]types.FreeSlot, error) {
+	for _, participantSchedule := range schedules {
+		if len(participantSchedule.Weeks) == 0 {
+			return nil, &types.UnavailableScheduleError{Scope: types.FullScope}
+		}
+	}
+
+	freeSlots := make([]types.FreeSlot, 0)
+	year, month, day := from.Date()
+	firstDate := time.Date(year, month, day, 0, 0, 0, 0, from.Location())
+	year, month, day = to.Date()
+	lastDate := time.Date(year, month, day, 0, 0, 0, 0, from.Location())
+
+	for date := firstDate; !date.After(lastDate); date = date.AddDate(0, 0, 1) {
+		if date.Weekday() == time.Sunday {
+			continue
+		}
+
+		busySlots := getBusySlots(schedules, date)
+
+		for slotIdx := range types.DefaultTimeTable.Slots {
+			if !isSlotFree(busySlots, slotIdx) {
+				continue
+			}
+
+			freeSlot := types.FreeSlot{Date: date, Duration: types.Duration(slotIdx)}
+			for _, participantSlots := range busySlots {
+				if len(participantSlots) == 0 {
+					freeSlot.FreeDayNum++
+					continue
+				}
+				freeSlot.Gaps += getSlotGap(participantSlots, slotIdx)
+			}
+			freeSlots = append(freeSlots, freeSlot)
+		}
+	}
+	return freeSlots, nil
+}
+
+// SortFreeSlotsByGaps sorts the slots so that the slots that add fewer idle time slots to the participants go first,
+// then the slots on the days when fewer participants are free. The order of the equal slots is kept.
+func SortFreeSlotsByGaps(freeSlots []types.FreeSlot) {
+	sort.SliceStable(freeSlots, func(i, j int) bool {
+		if freeSlots[i].Gaps != freeSlots[j].Gaps {
+			return freeSlots[i].Gaps < freeSlots[j].Gaps
+		}
+		return freeSlots[i].FreeDayNum < freeSlots[j].FreeDayNum
+	})
+}
+
+// getBusySlots returns the indexes of the time slots with lessons of each schedule on the date in ascending order.
+// The schedules must have weeks.
+func getBusySlots(schedules []*types.Schedule, date time.Time) [][]int {
+	// the noon is used to choose the week, because the week ranges do not include their bounds
+	noon := date.Add(12 * time.Hour)
+
+	busySlots := make([][]int, 0, len(schedules))
+	for _, participantSchedule := range schedules {
+		participantSlots := make([]int, 0)
+		if day, ok := getScheduleDayByDate(participantSchedule, noon); ok {
+			for lessonIdx, lesson := range day.Lessons {
+				if len(lesson.SubLessons) > 0 {
+					participantSlots = append(participantSlots, lessonIdx)
+				}
+			}
+		}
+		busySlots = append(busySlots, participantSlots)
+	}
+	return busySlots
+}
+
+// isSlotFree returns true if none of the participants has the lesson in the time slot, otherwise - false.
+func isSlotFree(busySlots [][]int, slotIdx int) bool {
+	for _, participantSlots := range busySlots {
+		for _, busySlotIdx := range participantSlots {
+			if busySlotIdx == slotIdx {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// getSlotGap returns the number of the idle time slots between the slot and the nearest lesson of the participant.
+// The slot between the lessons adds no idle time slots, because the participant waits there anyway.
+func getSlotGap(participantSlots []int, slotIdx int) int {
+	first, last := participantSlots[0], participantSlots[len(participantSlots)-1]
+	switch {
+	case slotIdx < first:
+		return first - slotIdx - 1
+	case slotIdx > last:
+		return slotIdx - last - 1
+	default:
+		return 0
+	}
+}
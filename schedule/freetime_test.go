@@ -0,0 +1,83 @@
+package schedule
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/mock"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+func TestFindCommonFreeSlots(t *testing.T) {
+	schedules := []*types.Schedule{mock.TestGroupSchedule(t), mock.TestTeacherSchedule(t)}
+	tuesday := time.Date(2024, 4, 16, 0, 0, 0, 0, time.UTC)
+
+	// the group has the lessons in the slots 1-4 and the teacher has the lesson in the slot 2
+	freeSlots, err := FindCommonFreeSlots(schedules, tuesday.Add(15*time.Hour), tuesday)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []types.FreeSlot{
+		{Date: tuesday, Duration: 0, Gaps: 1},
+		{Date: tuesday, Duration: 5, Gaps: 2},
+		{Date: tuesday, Duration: 6, Gaps: 4},
+		{Date: tuesday, Duration: 7, Gaps: 6},
+	}, freeSlots)
+
+	// saturday is free for both, sunday is skipped
+	saturday := time.Date(2024, 4, 20, 0, 0, 0, 0, time.UTC)
+	freeSlots, err = FindCommonFreeSlots(schedules, saturday, saturday.AddDate(0, 0, 1))
+	assert.NoError(t, err)
+	if assert.Len(t, freeSlots, len(types.DefaultTimeTable.Slots)) {
+		for _, freeSlot := range freeSlots {
+			assert.EqualValues(t, saturday, freeSlot.Date)
+			assert.EqualValues(t, 2, freeSlot.FreeDayNum)
+			assert.EqualValues(t, 0, freeSlot.Gaps)
+		}
+	}
+
+	// the weeks after the published ones are resolved by the rotation
+	freeSlots, err = FindCommonFreeSlots(schedules, time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	for _, freeSlot := range freeSlots {
+		assert.NotContains(t, []types.Duration{1, 2}, freeSlot.Duration)
+	}
+
+	// the participant with no lessons in the week does not make the day unavailable for the others
+	freeTeacherSchedule := mock.TestTeacherSchedule(t)
+	freeTeacherSchedule.Weeks[1] = types.Week{Number: freeTeacherSchedule.Weeks[1].Number,
+		DateStart: freeTeacherSchedule.Weeks[1].DateStart, DateEnd: freeTeacherSchedule.Weeks[1].DateEnd}
+	freeSlots, err = FindCommonFreeSlots([]*types.Schedule{mock.TestGroupSchedule(t), freeTeacherSchedule}, tuesday,
+		tuesday)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []types.FreeSlot{
+		{Date: tuesday, Duration: 0, FreeDayNum: 1},
+		{Date: tuesday, Duration: 5, FreeDayNum: 1},
+		{Date: tuesday, Duration: 6, Gaps: 1, FreeDayNum: 1},
+		{Date: tuesday, Duration: 7, Gaps: 2, FreeDayNum: 1},
+	}, freeSlots)
+
+	_, err = FindCommonFreeSlots([]*types.Schedule{mock.TestGroupSchedule(t), {}}, tuesday, tuesday)
+	assert.True(t, errors.Is(err, types.ErrNotPublished))
+}
+
+func TestSortFreeSlotsByGaps(t *testing.T) {
+	monday := time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)
+	freeSlots := []types.FreeSlot{
+		{Date: monday, Duration: 0, Gaps: 2},
+		{Date: monday, Duration: 5, Gaps: 0, FreeDayNum: 1},
+		{Date: monday, Duration: 6, Gaps: 1},
+		{Date: monday.AddDate(0, 0, 1), Duration: 0, Gaps: 0},
+		{Date: monday.AddDate(0, 0, 1), Duration: 1, Gaps: 0},
+	}
+
+	SortFreeSlotsByGaps(freeSlots)
+	assert.EqualValues(t, []types.FreeSlot{
+		{Date: monday.AddDate(0, 0, 1), Duration: 0, Gaps: 0},
+		{Date: monday.AddDate(0, 0, 1), Duration: 1, Gaps: 0},
+		{Date: monday, Duration: 5, Gaps: 0, FreeDayNum: 1},
+		{Date: monday, Duration: 6, Gaps: 1},
+		{Date: monday, Duration: 0, Gaps: 2},
+	}, freeSlots)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -15,20 +16,33 @@ import (
 )
 
 const (
-	teacherPattern     = `([А-Яа-яё]+ [А-Я] [А-Я])|(АДП П.П.)|([Прpеeпоoдаaватели]{13} [каaфеeдры]{7})`
-	roomPattern        = `(\d.*[-_].+)|(\d)|(([А-Я]+-)+\d+)`
-	subgroupPattern    = `\d п/г`
-	practicePattern    = ` Предприятие`
-	startDayWeekPatter = `(\d{2})|(\d)`
+	// pseudoTeacherPattern matches the names that are used instead of the teacher name
+	pseudoTeacherPattern = `(АДП П.П.)|([Прpеeпоoдаaватели]{13} [каaфеeдры]{7})`
+	teacherPattern       = `([А-Яа-яё]+ [А-Я] [А-Я])|` + pseudoTeacherPattern
+	roomPattern          = `(\d.*[-_].+)|(\d)|(([А-Я]+-)+\d+)`
+	subgroupPattern      = `\d п/г`
+	practicePattern      = ` Предприятие`
+	startDayWeekPatter   = `(\d{2})|(\d)`
+	coursePattern        = `(\d+)\s*курс`
+	groupNamePattern     = `^([А-ЯЁA-Z]+)([а-яёa-z]*)-?(\d*)`
 
 	headingTableGroupFontSize = 42
 )
 
+// groupScheduleParts contains the names of the parts of the group schedule. Each part lists the groups of its
+// faculties.
+var groupScheduleParts = [4]string{
+	"Часть 1 - МФ, РТФ, ЭФ (очная, очно-заочная формы обучения), ИФМИ, группы исскуственного интелекта (магистр)",
+	"Часть 2 – ФИСТ, ГФ",
+	"Часть 3 – ИАТУ, СФ, ИЭФ (очная, очно-заочная, заочная формы обучения), ЗВФ ИННО (очно-заочная, заочная формы обучения)",
+	"Часть 4 – КЭИ",
+}
+
 var groupScheduleURLs = [4]string{
-	strings.Replace("https://lk.ulstu.ru/timetable/shared/schedule/"+url.QueryEscape("Часть 1 - МФ, РТФ, ЭФ (очная, очно-заочная формы обучения), ИФМИ, группы исскуственного интелекта (магистр)"), "+", "%20", -1),
-	strings.Replace("https://lk.ulstu.ru/timetable/shared/schedule/"+url.QueryEscape("Часть 2 – ФИСТ, ГФ"), "+", "%20", -1),
-	strings.Replace("https://lk.ulstu.ru/timetable/shared/schedule/"+url.QueryEscape("Часть 3 – ИАТУ, СФ, ИЭФ (очная, очно-заочная, заочная формы обучения), ЗВФ ИННО (очно-заочная, заочная формы обучения)"), "+", "%20", -1),
-	strings.Replace("https://lk.ulstu.ru/timetable/shared/schedule/"+url.QueryEscape("Часть 4 – КЭИ"), "+", "%20", -1),
+	strings.Replace("https://lk.ulstu.ru/timetable/shared/schedule/"+url.QueryEscape(groupScheduleParts[0]), "+", "%20", -1),
+	strings.Replace("https://lk.ulstu.ru/timetable/shared/schedule/"+url.QueryEscape(groupScheduleParts[1]), "+", "%20", -1),
+	strings.Replace("https://lk.ulstu.ru/timetable/shared/schedule/"+url.QueryEscape(groupScheduleParts[2]), "+", "%20", -1),
+	strings.Replace("https://lk.ulstu.ru/timetable/shared/schedule/"+url.QueryEscape(groupScheduleParts[3]), "+", "%20", -1),
 }
 
 var (
@@ -38,39 +52,166 @@ var (
 	findRoom           = regexp.MustCompile(roomPattern)
 	findSubGroup       = regexp.MustCompile(subgroupPattern)
 	findStartDayWeek   = regexp.MustCompile(startDayWeekPatter)
+	findCourse         = regexp.MustCompile(coursePattern)
+	findGroupNameParts = regexp.MustCompile(groupNamePattern)
 
 	roomReplacer       = strings.NewReplacer(".", "", "_", "-", " - ", "-", " -", "-", "- ", "-")
 	afterSpecCharAdder = strings.NewReplacer(",", ", ", ".", ". ", "- ", " - ", " -", " - ", "&#34;", "'")
 )
 
-// GetGroups returns all available group names from UlSTU site.
+// GetGroups returns all available group names from UlSTU site. The parts of the schedule that failed to load or
+// parse are skipped.
+//
+// Deprecated: use GetGroupsInfo, which reports the errors.
 func GetGroups() []string {
 	// there cannot be more than 400 groups
 	groups := make([]string, 0, 400)
 
-	for _, scheduleURL := range groupScheduleURLs {
-		doc, err := getDocFromURL(scheduleURL + "/raspisan.html")
+	for partIdx := range groupScheduleURLs {
+		partGroups, err := getPartGroupsInfo(partIdx)
 		if err != nil {
 			continue
 		}
 
-		doc.Find("td").Each(func(i int, s *goquery.Selection) {
-			foundGroupName := s.Find("font").Text()
-			if foundGroupName != "" && !strings.Contains(foundGroupName, "курс") {
-				if strings.Contains(foundGroupName, ", ") {
-					foundGroupNames := strings.Split(foundGroupName, ", ")
-					for _, foundGroupName = range foundGroupNames {
-						groups = append(groups, foundGroupName)
-					}
+		for _, groupInfo := range partGroups {
+			groups = append(groups, groupInfo.Name)
+		}
+	}
+	return groups
+}
+
+// GetGroupsInfo returns all available groups with their metadata from UlSTU site.
+func GetGroupsInfo() ([]types.GroupInfo, error) {
+	// there cannot be more than 400 groups
+	groups := make([]types.GroupInfo, 0, 400)
+
+	for partIdx := range groupScheduleURLs {
+		partGroups, err := getPartGroupsInfo(partIdx)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, partGroups...)
+	}
+	return groups, nil
+}
+
+// getPartGroupsInfo returns the groups listed on the page of the part of the schedule from UlSTU site.
+func getPartGroupsInfo(partIdx int) (_ []types.GroupInfo, err error) {
+	pageURL := groupScheduleURLs[partIdx] + "/raspisan.html"
+	defer recoverParseError(&err, &pageURL, "", "group list")
+
+	doc, err := getDocFromURL(pageURL)
+	if err != nil {
+		return nil, err
+	}
+	return parseGroupList(doc, partIdx), nil
+}
+
+// parseGroupList returns the groups listed on the page of the part of the schedule. The course of the group is taken
+// from the "N курс" heading cells above the group, or from the group name if there are no headings.
+func parseGroupList(doc *goquery.Document, partIdx int) []types.GroupInfo {
+	groups := make([]types.GroupInfo, 0, 100)
+	faculties := getPartFaculties(groupScheduleParts[partIdx])
+
+	// maps the index of the cell in the row to the course, allColumnsCourse is used for the heading of the whole table
+	courseByColumn := map[int]int{}
+	allColumnsCourse := 0
+
+	doc.Find("tr").Each(func(_ int, rowS *goquery.Selection) {
+		rowS.ChildrenFiltered("td").Each(func(cellIdx int, cellS *goquery.Selection) {
+			foundGroupName := cellS.Find("font").Text()
+			if foundGroupName == "" {
+				return
+			}
+
+			if course := findCourse.FindStringSubmatch(foundGroupName); course != nil {
+				courseNum, _ := strconv.Atoi(course[1])
+				if rowS.ChildrenFiltered("td").Length() == 1 {
+					allColumnsCourse = courseNum
+					courseByColumn = map[int]int{}
 				} else {
-					groups = append(groups, foundGroupName)
+					courseByColumn[cellIdx] = courseNum
+				}
+				return
+			}
+			if strings.Contains(foundGroupName, "курс") {
+				return
+			}
+
+			href, _ := cellS.Find("a").Attr("href")
+			for _, groupName := range strings.Split(foundGroupName, ", ") {
+				groupInfo := newGroupInfo(groupName)
+				groupInfo.Part = partIdx + 1
+				groupInfo.Faculties = faculties
+				groupInfo.URL = groupScheduleURLs[partIdx] + "/" + href
+				if course, ok := courseByColumn[cellIdx]; ok {
+					groupInfo.Course = course
+				} else if allColumnsCourse != 0 {
+					groupInfo.Course = allColumnsCourse
 				}
+				groups = append(groups, groupInfo)
 			}
 		})
-	}
+	})
+
 	return groups
 }
 
+// newGroupInfo returns types.GroupInfo with the metadata encoded in the group name. The uppercase letters are
+// the specialty, the first lowercase letter is the degree, the next ones are the study form, and the first digit of
+// the number is the course (e.g. "ИДбв-11": bachelor, part-time, 1st course).
+func newGroupInfo(groupName string) types.GroupInfo {
+	groupInfo := types.GroupInfo{Name: groupName, StudyForm: types.FullTime, Degree: types.UnknownDegree}
+
+	nameParts := findGroupNameParts.FindStringSubmatch(groupName)
+	if nameParts == nil {
+		return groupInfo
+	}
+
+	for letterIdx, letter := range []rune(nameParts[2]) {
+		if letterIdx == 0 {
+			groupInfo.Degree = determineDegree(letter)
+			continue
+		}
+		switch letter {
+		case 'в':
+			groupInfo.StudyForm = types.PartTime
+		case 'з':
+			groupInfo.StudyForm = types.Extramural
+		}
+	}
+
+	if nameParts[3] != "" {
+		groupInfo.Course = int(nameParts[3][0] - '0')
+	}
+
+	return groupInfo
+}
+
+// determineDegree returns types.Degree representation of the letter in the group name.
+func determineDegree(letter rune) types.Degree {
+	switch letter {
+	case 'б':
+		return types.Bachelor
+	case 'м':
+		return types.Master
+	case 'с':
+		return types.Specialist
+	default:
+		return types.UnknownDegree
+	}
+}
+
+// getPartFaculties returns the faculties from the name of the part of the schedule ("Часть 2 – ФИСТ, ГФ" -> "ФИСТ, ГФ").
+func getPartFaculties(partName string) string {
+	for _, separator := range []string{" – ", " - "} {
+		if sepIdx := strings.Index(partName, separator); sepIdx != -1 {
+			return strings.TrimSpace(partName[sepIdx+len(separator):])
+		}
+	}
+	return partName
+}
+
 // GetTextDayGroupSchedule returns a text representation of the day schedule.
 func GetTextDayGroupSchedule(groupName string, daysAfterCurr int) (string, error) {
 	schedule, err := GetDayGroupSchedule(groupName, daysAfterCurr)
@@ -142,7 +283,20 @@ func GetFullGroupSchedule(groupName string) (*types.Schedule, error) {
 		return nil, err
 	}
 
-	return getFullSchedule(groupName, groupScheduleURL, types.Group)
+	return getFullSchedule(groupName, groupScheduleURL, types.Group, nil)
+}
+
+// GetFullGroupScheduleWithDiagnostics returns the full group's schedule and the warnings about the table cells that
+// were not parsed or were parsed with assumptions.
+func GetFullGroupScheduleWithDiagnostics(groupName string) (*types.Schedule, []types.ParseWarning, error) {
+	groupScheduleURL, err := getGroupScheduleURL(groupName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	diag := &diagnostics{}
+	schedule, err := getFullSchedule(groupName, groupScheduleURL, types.Group, diag)
+	return schedule, diag.warnings, err
 }
 
 // ParseCurrWeekGroupScheduleImg returns the path to the image with the week schedule based on the current school week.
@@ -306,7 +460,7 @@ func formatLessonNameToFitIntoCell(lessonName string) string {
 
 // parseGroupLesson returns *types.Lesson received from the HTML table cell.
 func parseGroupLesson(groupName string, lessonIdx int, reFindTeacherAndRoom, reFindTeacher, reFindRoom *regexp.Regexp,
-	reFindSubGroup *regexp.Regexp, s *goquery.Selection) *types.Lesson {
+	reFindSubGroup *regexp.Regexp, s *goquery.Selection, warn warnFunc) *types.Lesson {
 	lesson := &types.Lesson{}
 	tableCellHTML, _ := s.Find("font").Html()
 	// if the table cell contains the lesson info
@@ -319,9 +473,14 @@ func parseGroupLesson(groupName string, lessonIdx int, reFindTeacherAndRoom, reF
 			// each lesson corresponds to 1 or more teachers and rooms that follow it
 			subLessonName      string
 			subGroupLessonMain string
+			// the name of the lesson is dropped if no teacher, room or practice follows it
+			isNameUsed = true
 		)
 		// <br/> separates the name of the lesson with the teacher and the audience number
 		splitLessonInfoHTML := strings.Split(tableCellHTML, " <br/>")
+		if lastFragment := strings.TrimSpace(splitLessonInfoHTML[len(splitLessonInfoHTML)-1]); lastFragment != "" {
+			warn(types.UnparsedFragment, lastFragment)
+		}
 		// if <br/> doesn't separate anything, so we do not take it into account
 		for j := 0; j < len(splitLessonInfoHTML)-1; j++ {
 			// if the row contains teacher and room
@@ -338,28 +497,42 @@ func parseGroupLesson(groupName string, lessonIdx int, reFindTeacherAndRoom, reF
 				}
 
 				lesson.SubLessons = append(lesson.SubLessons, types.SubLesson{
-					Duration: types.Duration(lessonIdx),
-					Type:     subLessonType,
-					Group:    groupName,
-					Name:     subLessonName,
-					Teacher:  reFindTeacher.FindString(lessonWithoutSubGroup),
-					Room:     roomReplacer.Replace(room), // remove extra characters from the room
-					SubGroup: subGroupLesson,
+					Duration:    types.Duration(lessonIdx),
+					Type:        subLessonType,
+					Group:       groupName,
+					Name:        subLessonName,
+					Teacher:     reFindTeacher.FindString(lessonWithoutSubGroup),
+					Room:        roomReplacer.Replace(room), // remove extra characters from the room
+					SubGroup:    subGroupLesson,
+					SubGroupNum: types.ParseSubGroupNum(subGroupLesson),
 				})
+				isNameUsed = true
 			} else if findPractice.MatchString(splitLessonInfoHTML[j]) {
 				lesson.SubLessons = append(lesson.SubLessons, types.SubLesson{
 					Type:     subLessonType,
 					Name:     subLessonName,
 					Practice: splitLessonInfoHTML[j],
 				})
+				isNameUsed = true
 			} else {
+				if !isNameUsed {
+					warn(types.UnparsedFragment, subLessonName)
+				}
+				isNameUsed = false
+
 				if j == 0 {
 					subLessonName, subGroupLessonMain, subLessonType = getLessonInfo(splitLessonInfoHTML[j], reFindSubGroup)
+					if subLessonType == types.Unknown {
+						warn(types.UnknownLessonTypeFragment, splitLessonInfoHTML[j])
+					}
 				} else {
 					subLessonName, subGroupLessonMain = getLessonInfoWithoutType(splitLessonInfoHTML[j], reFindSubGroup)
 				}
 			}
 		}
+		if !isNameUsed {
+			warn(types.UnparsedFragment, subLessonName)
+		}
 	}
 	return lesson
 }
@@ -396,50 +569,52 @@ func getSubLessonName(name string, subGroupLesson string) string {
 	return name
 }
 
-// getGroupScheduleURL returns the url to the group's schedule on UlSTU site.
-func getGroupScheduleURL(groupName string) (string, error) {
+// getGroupScheduleURL returns the url to the group's schedule on UlSTU site. Returns *types.NotFoundError if there
+// is no such group in the lists of groups.
+func getGroupScheduleURL(groupName string) (_ string, err error) {
 	groupURL := ""
+	// all the found group names are used to suggest the nearest names if the group is not found
+	groupNames := make([]string, 0, 400)
+	// the group may be in the list that was not loaded
+	var listErr error
+
+	pageURL := ""
+	defer recoverParseError(&err, &pageURL, groupName, "group list")
 
-	for _, scheduleURL := range groupScheduleURLs {
-		doc, err := getDocFromURL(scheduleURL + "/raspisan.html")
+	for partIdx, scheduleURL := range groupScheduleURLs {
+		pageURL = scheduleURL + "/raspisan.html"
+		doc, err := getDocFromURL(pageURL)
 		if err != nil {
+			listErr = err
 			continue
 		}
 
-		doc.Find("td").EachWithBreak(func(i int, s *goquery.Selection) bool {
-			foundGroupName := s.Find("font").Text()
-			if foundGroupName != "" {
-				if strings.Contains(foundGroupName, ", ") {
-					foundGroupNames := strings.Split(foundGroupName, ", ")
-					for _, foundGroupName = range foundGroupNames {
-						if foundGroupName == groupName {
-							href, _ := s.Find("a").Attr("href")
-							groupURL = scheduleURL + "/" + href
-							return false
-						}
-					}
-				} else if foundGroupName == groupName {
-					href, _ := s.Find("a").Attr("href")
-					groupURL = scheduleURL + "/" + href
-					return false
-				}
+		for _, groupInfo := range parseGroupList(doc, partIdx) {
+			groupNames = append(groupNames, groupInfo.Name)
+			if groupInfo.Name == groupName {
+				groupURL = groupInfo.URL
+				break
 			}
-			return true
-		})
+		}
 
 		if groupURL != "" {
+			pageURL = groupURL
 			doc, err = getDocFromURL(groupURL)
 			if err != nil {
 				return "", err
 			}
 
-			groupNameFromDoc := doc.Find("p").Get(0).LastChild.FirstChild.Data
-			if !strings.Contains(groupNameFromDoc, groupName) {
-				return "", &types.IncorrectLinkError{Name: groupName, NameFromURL: groupNameFromDoc}
+			if err = checkScheduleTitle(doc, groupName, groupURL); err != nil {
+				return "", err
 			}
 
 			return groupURL, nil
 		}
 	}
-	return groupURL, nil
+
+	if listErr != nil {
+		return "", listErr
+	}
+
+	return "", &types.NotFoundError{Name: groupName, Type: types.Group, Suggestions: suggestNames(groupName, groupNames, types.Group)}
 }
@@ -1,9 +1,14 @@
 package schedule
 
 import (
+	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/ulstu-schedule/parser/mock"
+	"github.com/ulstu-schedule/parser/types"
+	"golang.org/x/text/encoding/charmap"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"regexp"
 	"testing"
@@ -69,3 +74,115 @@ func TestConvertDayGroupScheduleToText(t *testing.T) {
 		assert.EqualValues(t, true, findStart.MatchString(result))
 	})
 }
+
+func setGroupScheduleURLs(t *testing.T, scheduleURL string) {
+	prevGroupScheduleURLs := groupScheduleURLs
+	groupScheduleURLs = [4]string{scheduleURL, scheduleURL, scheduleURL, scheduleURL}
+	t.Cleanup(func() {
+		groupScheduleURLs = prevGroupScheduleURLs
+	})
+}
+
+func TestGetGroups(t *testing.T) {
+	t.Run("correct", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			page, _ := charmap.Windows1251.NewEncoder().String(
+				`<table><tr><td><a href="1.html"><font>АТсд-11</font></a></td></tr></table>`)
+			_, _ = fmt.Fprint(w, page)
+		}))
+		defer server.Close()
+		setGroupScheduleURLs(t, server.URL)
+
+		groupsInfo, err := GetGroupsInfo()
+		assert.NoError(t, err)
+		assert.Len(t, groupsInfo, 4)
+		assert.EqualValues(t, []string{"АТсд-11", "АТсд-11", "АТсд-11", "АТсд-11"}, GetGroups())
+	})
+	t.Run("site unavailable", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+		setGroupScheduleURLs(t, server.URL)
+
+		_, err := GetGroupsInfo()
+		assert.True(t, errors.Is(err, types.ErrSiteUnavailable))
+		assert.Empty(t, GetGroups())
+	})
+}
+
+func TestGetGroupScheduleURL(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			page, _ := charmap.Windows1251.NewEncoder().String(`<table><tr><td><font>1 курс</font></td></tr>` +
+				`<tr><td><a href="1.html"><font>АТсд-11</font></a></td><td><a href="2.html"><font>АТсд-21</font></a></td></tr></table>`)
+			_, _ = fmt.Fprint(w, page)
+		}))
+		defer server.Close()
+		setGroupScheduleURLs(t, server.URL)
+
+		_, err := getGroupScheduleURL("АТсд-31")
+
+		var notFoundErr *types.NotFoundError
+		assert.True(t, errors.As(err, &notFoundErr))
+		assert.True(t, errors.Is(err, types.ErrNotFound))
+		assert.EqualValues(t, types.Group, notFoundErr.Type)
+		assert.Contains(t, notFoundErr.Suggestions, "АТсд-21")
+		assert.NotContains(t, notFoundErr.Suggestions, "1 курс")
+	})
+	t.Run("site unavailable", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+		setGroupScheduleURLs(t, server.URL)
+
+		_, err := getGroupScheduleURL("АТсд-31")
+
+		assert.True(t, errors.Is(err, types.ErrSiteUnavailable))
+		assert.False(t, errors.Is(err, types.ErrNotFound))
+	})
+}
+
+func TestNewGroupInfo(t *testing.T) {
+	tests := []struct {
+		name      string
+		degree    types.Degree
+		studyForm types.StudyForm
+		course    int
+	}{
+		{name: "АТсд-21", degree: types.Specialist, studyForm: types.FullTime, course: 2},
+		{name: "ИДбв-11", degree: types.Bachelor, studyForm: types.PartTime, course: 1},
+		{name: "ЭМмз-11", degree: types.Master, studyForm: types.Extramural, course: 1},
+		{name: "ПГСбву-31", degree: types.Bachelor, studyForm: types.PartTime, course: 3},
+		{name: "ИСТ-41", degree: types.UnknownDegree, studyForm: types.FullTime, course: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groupInfo := newGroupInfo(tt.name)
+
+			assert.EqualValues(t, tt.name, groupInfo.Name)
+			assert.EqualValues(t, tt.degree, groupInfo.Degree)
+			assert.EqualValues(t, tt.studyForm, groupInfo.StudyForm)
+			assert.EqualValues(t, tt.course, groupInfo.Course)
+		})
+	}
+}
+
+func TestParseGroupList(t *testing.T) {
+	doc := testScheduleDoc(t, `<table>`+
+		`<tr><td><font>1 курс</font></td><td><font>2 курс</font></td></tr>`+
+		`<tr><td><a href="1.html"><font>АТсд-11</font></a></td><td><a href="2.html"><font>АТсд-31, АТсд-32</font></a></td></tr>`+
+		`</table>`)
+
+	groups := parseGroupList(doc, 1)
+
+	assert.Len(t, groups, 3)
+	assert.EqualValues(t, "АТсд-11", groups[0].Name)
+	assert.EqualValues(t, 1, groups[0].Course)
+	assert.EqualValues(t, 2, groups[0].Part)
+	assert.EqualValues(t, "ФИСТ, ГФ", groups[0].Faculties)
+	assert.EqualValues(t, groupScheduleURLs[1]+"/1.html", groups[0].URL)
+
+	// the course from the heading takes precedence over the course from the name
+	assert.EqualValues(t, "АТсд-32", groups[2].Name)
+	assert.EqualValues(t, 2, groups[2].Course)
+	assert.EqualValues(t, groupScheduleURLs[1]+"/2.html", groups[2].URL)
+}
@@ -0,0 +1,155 @@
+package schedule
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ulstu-schedule/parser/types"
+)
+
+const (
+	icsTimeFormat = "20060102T150405Z"
+	// icsMaxLineLen is the maximum length of the line in octets, the longer lines are folded
+	icsMaxLineLen = 75
+)
+
+// scheduleLocation is the time zone of the university (Ulyanovsk, UTC+4).
+var scheduleLocation = getScheduleLocation()
+
+// icsEscaper escapes the special characters of the iCalendar text values.
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+// ConvertScheduleToICS converts the published weeks of the schedule into the iCalendar format. Every SubLesson of
+// the week with known dates becomes an event, the same SubLessons of different groups in one slot are merged.
+func ConvertScheduleToICS(schedule *types.Schedule, name string, typeSchedule types.ScheduleType) string {
+	sb := &strings.Builder{}
+	writeICSLine(sb, "BEGIN:VCALENDAR")
+	writeICSLine(sb, "VERSION:2.0")
+	writeICSLine(sb, "PRODID:-//ulstu-schedule//parser//RU")
+	writeICSLine(sb, "CALSCALE:GREGORIAN")
+	writeICSLine(sb, "X-WR-CALNAME:"+icsEscaper.Replace(name))
+
+	nameHash := sha1.Sum([]byte(typeSchedule.String() + ":" + name))
+	uidSuffix := hex.EncodeToString(nameHash[:8]) + "@ulstu-schedule"
+	stamp := time.Now().UTC().Format(icsTimeFormat)
+
+	for _, week := range schedule.Weeks {
+		if week.DateStart.IsZero() {
+			continue
+		}
+		year, month, day := week.DateStart.Date()
+		weekStart := time.Date(year, month, day, 0, 0, 0, 0, scheduleLocation)
+
+		for dayIdx, day := range week.Days {
+			date := weekStart.AddDate(0, 0, dayIdx)
+
+			for lessonIdx, lesson := range day.Lessons {
+				start, end, ok := types.Duration(lessonIdx).TimeRange(date, types.DefaultTimeTable)
+				if !ok {
+					continue
+				}
+
+				for eventIdx, event := range getICSEvents(lesson, typeSchedule) {
+					writeICSLine(sb, "BEGIN:VEVENT")
+					writeICSLine(sb, fmt.Sprintf("UID:%s-%d-%d-%s", date.Format("20060102"), lessonIdx, eventIdx,
+						uidSuffix))
+					writeICSLine(sb, "DTSTAMP:"+stamp)
+					writeICSLine(sb, "DTSTART:"+start.UTC().Format(icsTimeFormat))
+					writeICSLine(sb, "DTEND:"+end.UTC().Format(icsTimeFormat))
+					writeICSLine(sb, "SUMMARY:"+icsEscaper.Replace(event.summary))
+					if event.location != "" {
+						writeICSLine(sb, "LOCATION:"+icsEscaper.Replace(event.location))
+					}
+					if event.description != "" {
+						writeICSLine(sb, "DESCRIPTION:"+icsEscaper.Replace(event.description))
+					}
+					writeICSLine(sb, "END:VEVENT")
+				}
+			}
+		}
+	}
+
+	writeICSLine(sb, "END:VCALENDAR")
+	return sb.String()
+}
+
+// icsEvent is the calendar event made of the SubLessons.
+type icsEvent struct {
+	summary     string
+	location    string
+	description string
+}
+
+// getICSEvents returns the calendar events of the lesson. The SubLessons with the same name, type and room are
+// merged into one event listing all their groups or teachers.
+func getICSEvents(lesson types.Lesson, typeSchedule types.ScheduleType) []icsEvent {
+	events := make([]icsEvent, 0, len(lesson.SubLessons))
+	participants := make([][]string, 0, len(lesson.SubLessons))
+
+	for _, subLesson := range lesson.SubLessons {
+		event := icsEvent{summary: strings.TrimSpace(fmt.Sprintf("%s %s", subLesson.Type,
+			strings.TrimSpace(subLesson.Name))), location: subLesson.Room}
+
+		participant := subLesson.Teacher
+		if typeSchedule != types.Group {
+			participant = subLesson.Group
+		}
+		if subLesson.SubGroup != "" {
+			participant = fmt.Sprintf("%s (%s)", participant, subLesson.SubGroup)
+		}
+		if subLesson.Practice != "" {
+			participant = strings.TrimSpace(participant + " " + subLesson.Practice)
+		}
+
+		eventIdx := -1
+		for i := range events {
+			if events[i] == event {
+				eventIdx = i
+				break
+			}
+		}
+		if eventIdx == -1 {
+			events = append(events, event)
+			participants = append(participants, nil)
+			eventIdx = len(events) - 1
+		}
+		if participant != "" {
+			participants[eventIdx] = append(participants[eventIdx], participant)
+		}
+	}
+
+	for eventIdx := range events {
+		events[eventIdx].description = strings.Join(participants[eventIdx], ", ")
+	}
+	return events
+}
+
+// writeICSLine writes the content line terminated with CRLF, folding it if it is longer than icsMaxLineLen octets.
+// The line is never split inside the UTF-8 character.
+func writeICSLine(sb *strings.Builder, line string) {
+	lineLen := 0
+	for _, r := range line {
+		runeLen := len(string(r))
+		if lineLen+runeLen > icsMaxLineLen {
+			sb.WriteString("\r\n ")
+			// the folded line starts with the space
+			lineLen = 1
+		}
+		sb.WriteRune(r)
+		lineLen += runeLen
+	}
+	sb.WriteString("\r\n")
+}
+
+// getScheduleLocation returns the time zone of the university, or the fixed UTC+4 zone if the time zone database is
+// not available.
+func getScheduleLocation() *time.Location {
+	location, err := time.LoadLocation("Europe/Ulyanovsk")
+	if err != nil {
+		return time.FixedZone("UTC+4", 4*60*60)
+	}
+	return location
+}
@@ -0,0 +1,49 @@
+package schedule
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/mock"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+func TestConvertScheduleToICS(t *testing.T) {
+	t.Run("group", func(t *testing.T) {
+		ics := ConvertScheduleToICS(mock.TestGroupSchedule(t), "АТсд-21", types.Group)
+
+		assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n"))
+		assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+		assert.EqualValues(t, 34, strings.Count(ics, "BEGIN:VEVENT"))
+		// Ulyanovsk is UTC+4, the second lesson starts at 10:00
+		assert.Contains(t, ics, "DTSTART:20240415T060000Z\r\nDTEND:20240415T072000Z\r\n")
+		assert.Contains(t, ics, "LOCATION:1-231\r\n")
+		assert.Contains(t, ics, "DESCRIPTION:Евстигнеев А Д (1 п/г)\r\n")
+		// the long lines are folded
+		unfoldedICS := strings.ReplaceAll(ics, "\r\n ", "")
+		assert.Contains(t, unfoldedICS, `SUMMARY:Лек. Электротехника\,  электроника и электропривод`)
+	})
+	t.Run("merged sub lessons", func(t *testing.T) {
+		schedule := mock.TestTeacherSchedule(t)
+		lesson := &schedule.Weeks[0].Days[2].Lessons[3]
+		subLesson := lesson.SubLessons[0]
+		subLesson.Group = "СОбд-22"
+		lesson.SubLessons = append(lesson.SubLessons, subLesson)
+
+		events := getICSEvents(*lesson, types.Teacher)
+		assert.Len(t, events, 1)
+		assert.EqualValues(t, "СОбд-21, СОбд-22", events[0].description)
+	})
+}
+
+func TestWriteICSLine(t *testing.T) {
+	sb := &strings.Builder{}
+	writeICSLine(sb, "SUMMARY:"+strings.Repeat("ы", 50))
+
+	lines := strings.Split(strings.TrimSuffix(sb.String(), "\r\n"), "\r\n")
+	assert.Len(t, lines, 2)
+	assert.True(t, len(lines[0]) <= icsMaxLineLen)
+	assert.True(t, strings.HasPrefix(lines[1], " "))
+	assert.EqualValues(t, "SUMMARY:"+strings.Repeat("ы", 50), lines[0]+lines[1][1:])
+}
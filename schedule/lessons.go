@@ -0,0 +1,87 @@
+package schedule
+
+import (
+	"math"
+	"time"
+
+	"github.com/ulstu-schedule/parser/types"
+)
+
+// CurrentLesson returns the lesson that is going on at the moment at. The lesson times are resolved against
+// types.DefaultTimeTable in the location of at. Returns nil if there is no lesson at the moment.
+func CurrentLesson(schedule *types.Schedule, at time.Time) (*types.DatedLesson, error) {
+	if len(schedule.Weeks) == 0 {
+		return nil, &types.UnavailableScheduleError{Scope: types.FullScope}
+	}
+
+	for _, lesson := range getDatedLessons(schedule, at, 0) {
+		if !at.Before(lesson.Start) && at.Before(lesson.End) {
+			return newDatedLesson(lesson, at), nil
+		}
+	}
+	return nil, nil
+}
+
+// NextLesson returns the first lesson that starts after the moment at. If the lessons of the day are over, the
+// following days are searched, including the other weeks of the rotation. The lesson times are resolved against
+// types.DefaultTimeTable in the location of at.
+func NextLesson(schedule *types.Schedule, at time.Time) (*types.DatedLesson, error) {
+	if len(schedule.Weeks) == 0 {
+		return nil, &types.UnavailableScheduleError{Scope: types.FullScope}
+	}
+
+	// the schedule repeats after the rotation, so the search is limited by it and the rest of the current week
+	maxDays := (schedule.RotationLen() + 1) * 7
+	for daysAfter := 0; daysAfter <= maxDays; daysAfter++ {
+		for _, lesson := range getDatedLessons(schedule, at, daysAfter) {
+			if lesson.Start.After(at) {
+				return newDatedLesson(lesson, at), nil
+			}
+		}
+	}
+	return nil, &types.UnavailableScheduleError{Scope: types.FullScope}
+}
+
+// getDatedLessons returns the lessons of the day that is daysAfter days after the date of at, in order of their
+// time slots. The Lessons without SubLessons are skipped.
+func getDatedLessons(schedule *types.Schedule, at time.Time, daysAfter int) []types.DatedLesson {
+	year, month, day := at.Date()
+	// the noon is used to choose the week, because the week ranges do not include their bounds
+	date := time.Date(year, month, day+daysAfter, 12, 0, 0, 0, at.Location())
+
+	weekNum := getScheduleWeekNumDyDate(schedule, date)
+	if weekNum < 0 || weekNum >= len(schedule.Weeks) {
+		return nil
+	}
+	_, weekDayNum := getWeekDateAndWeekDayByTime(date)
+
+	var lessons []types.DatedLesson
+	for lessonIdx, lesson := range schedule.Weeks[weekNum].Days[weekDayNum].Lessons {
+		if len(lesson.SubLessons) == 0 {
+			continue
+		}
+
+		start, end, ok := types.Duration(lessonIdx).TimeRange(date, types.DefaultTimeTable)
+		if !ok {
+			continue
+		}
+
+		lessons = append(lessons, types.DatedLesson{
+			WeekNum:    weekNum,
+			WeekDayNum: weekDayNum,
+			Duration:   types.Duration(lessonIdx),
+			Start:      start,
+			End:        end,
+			SubLessons: lesson.SubLessons,
+		})
+	}
+	return lessons
+}
+
+// newDatedLesson returns the copy of the lesson with the minutes left before its start at the moment at.
+func newDatedLesson(lesson types.DatedLesson, at time.Time) *types.DatedLesson {
+	if untilStart := lesson.Start.Sub(at); untilStart > 0 {
+		lesson.MinutesUntilStart = int(math.Ceil(untilStart.Minutes()))
+	}
+	return &lesson
+}
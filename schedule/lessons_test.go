@@ -0,0 +1,81 @@
+package schedule
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/mock"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+func TestCurrentLesson(t *testing.T) {
+	groupSchedule := mock.TestGroupSchedule(t)
+
+	lesson, err := CurrentLesson(groupSchedule, time.Date(2024, 4, 16, 10, 30, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	if assert.NotNil(t, lesson) {
+		assert.EqualValues(t, 0, lesson.WeekNum)
+		assert.EqualValues(t, 1, lesson.WeekDayNum)
+		assert.EqualValues(t, 1, lesson.Duration)
+		assert.EqualValues(t, time.Date(2024, 4, 16, 10, 0, 0, 0, time.UTC), lesson.Start)
+		assert.EqualValues(t, time.Date(2024, 4, 16, 11, 20, 0, 0, time.UTC), lesson.End)
+		assert.EqualValues(t, 0, lesson.MinutesUntilStart)
+		assert.EqualValues(t, groupSchedule.Weeks[0].Days[1].Lessons[1].SubLessons, lesson.SubLessons)
+	}
+
+	// the break between the lessons
+	lesson, err = CurrentLesson(groupSchedule, time.Date(2024, 4, 16, 12, 55, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.Nil(t, lesson)
+
+	// the end of the lesson is not included
+	lesson, err = CurrentLesson(groupSchedule, time.Date(2024, 4, 16, 16, 20, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.Nil(t, lesson)
+
+	_, err = CurrentLesson(&types.Schedule{}, time.Now())
+	assert.True(t, errors.Is(err, types.ErrNotPublished))
+}
+
+func TestNextLesson(t *testing.T) {
+	groupSchedule := mock.TestGroupSchedule(t)
+
+	testCases := []struct {
+		at                time.Time
+		weekNum           int
+		weekDayNum        int
+		duration          types.Duration
+		start             time.Time
+		minutesUntilStart int
+	}{
+		// the next lesson of the day
+		{time.Date(2024, 4, 16, 10, 30, 0, 0, time.UTC), 0, 1, 2, time.Date(2024, 4, 16, 11, 30, 0, 0, time.UTC), 60},
+		{time.Date(2024, 4, 16, 13, 29, 30, 0, time.UTC), 0, 1, 3, time.Date(2024, 4, 16, 13, 30, 0, 0, time.UTC), 1},
+		// the lessons of the week are over
+		{time.Date(2024, 4, 19, 18, 0, 0, 0, time.UTC), 1, 0, 1, time.Date(2024, 4, 22, 10, 0, 0, 0, time.UTC), 3840},
+		// the week after the published ones is chosen by the rotation
+		{time.Date(2024, 4, 27, 9, 0, 0, 0, time.UTC), 0, 0, 1, time.Date(2024, 4, 29, 10, 0, 0, 0, time.UTC), 2940},
+	}
+
+	for _, testCase := range testCases {
+		lesson, err := NextLesson(groupSchedule, testCase.at)
+		assert.Nil(t, err)
+		if assert.NotNil(t, lesson, testCase.at) {
+			assert.EqualValues(t, testCase.weekNum, lesson.WeekNum, testCase.at)
+			assert.EqualValues(t, testCase.weekDayNum, lesson.WeekDayNum, testCase.at)
+			assert.EqualValues(t, testCase.duration, lesson.Duration, testCase.at)
+			assert.EqualValues(t, testCase.start, lesson.Start, testCase.at)
+			assert.EqualValues(t, testCase.minutesUntilStart, lesson.MinutesUntilStart, testCase.at)
+			assert.NotEmpty(t, lesson.SubLessons, testCase.at)
+		}
+	}
+
+	_, err := NextLesson(&types.Schedule{}, time.Now())
+	assert.True(t, errors.Is(err, types.ErrNotPublished))
+
+	emptySchedule := &types.Schedule{Weeks: []types.Week{{Number: 1}, {Number: 2}}}
+	_, err = NextLesson(emptySchedule, time.Now())
+	assert.True(t, errors.Is(err, types.ErrNotPublished))
+}
@@ -0,0 +1,106 @@
+package schedule
+
+import (
+	"strings"
+
+	"github.com/ulstu-schedule/parser/types"
+)
+
+// GetScheduleLoad returns the load of each week of the group or teacher schedule in the order of the weeks.
+func GetScheduleLoad(schedule *types.Schedule) []types.WeekLoad {
+	weekLoads := make([]types.WeekLoad, 0, len(schedule.Weeks))
+	for weekIdx := range schedule.Weeks {
+		weekLoads = append(weekLoads, GetWeekLoad(&schedule.Weeks[weekIdx]))
+	}
+	return weekLoads
+}
+
+// GetWeekLoad returns the load of the days of the group or teacher week schedule and the totals over the week.
+func GetWeekLoad(week *types.Week) types.WeekLoad {
+	weekLoad := types.WeekLoad{WeekNumber: week.Number}
+	for dayIdx := range week.Days {
+		dayLoad := GetDayLoad(&week.Days[dayIdx])
+		weekLoad.Days[dayIdx] = dayLoad
+
+		if dayLoad.LessonsNum > 0 {
+			weekLoad.StudyDaysNum++
+		}
+		weekLoad.LessonsNum += dayLoad.LessonsNum
+		weekLoad.GapSlots += dayLoad.GapSlots
+		weekLoad.GapMinutes += dayLoad.GapMinutes
+		weekLoad.BuildingChanges += dayLoad.BuildingChanges
+	}
+	return weekLoad
+}
+
+// GetDayLoad returns the load of the group or teacher day schedule. The time of the lessons is taken from
+// types.DefaultTimeTable. The lesson is considered to take place in the building only if all its SubLessons are in
+// it, so the building changes of the subgroups are counted only for the schedule filtered by FilterDaySubGroup.
+func GetDayLoad(day *types.Day) types.DayLoad {
+	dayLoad := types.DayLoad{FirstLesson: -1, LastLesson: -1}
+	prevBuilding := ""
+
+	for lessonIdx, lesson := range day.Lessons {
+		if len(lesson.SubLessons) == 0 {
+			continue
+		}
+
+		if dayLoad.LastLesson != -1 && lessonIdx-dayLoad.LastLesson > 1 {
+			dayLoad.GapSlots += lessonIdx - dayLoad.LastLesson - 1
+			dayLoad.GapMinutes += getGapMinutes(dayLoad.LastLesson, lessonIdx)
+		}
+
+		// the lessons with the unknown building, e.g. the distance ones, are skipped when the buildings are compared
+		if building := getLessonBuilding(lesson); building != "" {
+			if prevBuilding != "" && building != prevBuilding {
+				dayLoad.BuildingChanges++
+			}
+			prevBuilding = building
+		}
+
+		if dayLoad.FirstLesson == -1 {
+			dayLoad.FirstLesson = lessonIdx
+		}
+		dayLoad.LastLesson = lessonIdx
+		dayLoad.LessonsNum++
+	}
+	return dayLoad
+}
+
+// getGapMinutes returns the number of minutes from the end of the lesson to the start of the next one. Returns 0 if
+// types.DefaultTimeTable has no time slots of the lessons.
+func getGapMinutes(lessonIdx, nextLessonIdx int) int {
+	lessonSlot, ok := types.Duration(lessonIdx).Slot(nil)
+	if !ok {
+		return 0
+	}
+	nextLessonSlot, ok := types.Duration(nextLessonIdx).Slot(nil)
+	if !ok {
+		return 0
+	}
+	return int((nextLessonSlot.Start - lessonSlot.End).Minutes())
+}
+
+// getLessonBuilding returns the building in which all the SubLessons of the lesson take place, or an empty string if
+// it is unknown or the SubLessons are in different buildings.
+func getLessonBuilding(lesson types.Lesson) string {
+	building := ""
+	for _, subLesson := range lesson.SubLessons {
+		subLessonBuilding := getRoomBuilding(subLesson.Room)
+		if subLessonBuilding == "" || building != "" && subLessonBuilding != building {
+			return ""
+		}
+		building = subLessonBuilding
+	}
+	return building
+}
+
+// getRoomBuilding returns the building of the room, which is the part of the room name before "-", e.g. "6" for
+// "6-401". Returns an empty string for the distance learning rooms and the rooms with no building.
+func getRoomBuilding(room string) string {
+	sepIdx := strings.Index(room, "-")
+	if sepIdx <= 0 || isDistanceRoom(room) {
+		return ""
+	}
+	return strings.TrimSpace(room[:sepIdx])
+}
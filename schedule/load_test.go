@@ -0,0 +1,72 @@
+package schedule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/mock"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+func TestGetDayLoad(t *testing.T) {
+	day := types.Day{Lessons: make([]types.Lesson, 8)}
+	day.Lessons[1].SubLessons = []types.SubLesson{{Name: "Философия", Room: "6-401"}}
+	day.Lessons[2].SubLessons = []types.SubLesson{{Name: "Физика", Room: "3-101"}}
+	// the gap between 11:30-12:50 and 15:00-16:20, the subgroups are in different buildings
+	day.Lessons[4].SubLessons = []types.SubLesson{
+		{Name: "Химия", Room: "3-102", SubGroup: "1 п/г"},
+		{Name: "Химия", Room: "6-210", SubGroup: "2 п/г"},
+	}
+	// the distance learning room has no building
+	day.Lessons[5].SubLessons = []types.SubLesson{{Name: "Математика", Room: "5-ДОТ"}}
+	day.Lessons[7].SubLessons = []types.SubLesson{{Name: "История", Room: "6-419"}}
+
+	assert.EqualValues(t, types.DayLoad{
+		FirstLesson: 1,
+		LastLesson:  7,
+		LessonsNum:  5,
+		GapSlots:    2,
+		GapMinutes:  130 + 100,
+		// from 6 to 3 and from 3 to 6 over the lessons with the unknown building
+		BuildingChanges: 2,
+	}, GetDayLoad(&day))
+
+	assert.EqualValues(t, types.DayLoad{FirstLesson: 1, LastLesson: 7, LessonsNum: 5, GapSlots: 2, GapMinutes: 230,
+		BuildingChanges: 2}, GetDayLoad(FilterDaySubGroup(&day, 2)))
+
+	assert.EqualValues(t, types.DayLoad{FirstLesson: -1, LastLesson: -1}, GetDayLoad(&types.Day{}))
+
+	// the distance lesson does not hide the change of the building
+	day = types.Day{Lessons: make([]types.Lesson, 3)}
+	day.Lessons[0].SubLessons = []types.SubLesson{{Name: "Философия", Room: "6-401"}}
+	day.Lessons[1].SubLessons = []types.SubLesson{{Name: "Математика", Room: "5-ДОТ"}}
+	day.Lessons[2].SubLessons = []types.SubLesson{{Name: "Физика", Room: "3-101"}}
+	assert.EqualValues(t, 1, GetDayLoad(&day).BuildingChanges)
+}
+
+func TestGetScheduleLoad(t *testing.T) {
+	groupSchedule := mock.TestGroupSchedule(t)
+
+	weekLoads := GetScheduleLoad(groupSchedule)
+	if !assert.Len(t, weekLoads, 2) {
+		return
+	}
+
+	weekLoad := weekLoads[0]
+	assert.EqualValues(t, 11, weekLoad.WeekNumber)
+	assert.EqualValues(t, 5, weekLoad.StudyDaysNum)
+	assert.EqualValues(t, 17, weekLoad.LessonsNum)
+	assert.EqualValues(t, 0, weekLoad.GapSlots)
+	assert.EqualValues(t, 4, weekLoad.BuildingChanges)
+
+	// the lessons in 6-401, 2-СЗ and 6-530
+	assert.EqualValues(t, types.DayLoad{FirstLesson: 1, LastLesson: 4, LessonsNum: 4, BuildingChanges: 2},
+		weekLoad.Days[1])
+	assert.EqualValues(t, types.DayLoad{FirstLesson: -1, LastLesson: -1}, weekLoad.Days[5])
+
+	teacherLoads := GetScheduleLoad(mock.TestTeacherSchedule(t))
+	if assert.Len(t, teacherLoads, 2) {
+		assert.EqualValues(t, 1, teacherLoads[1].StudyDaysNum)
+		assert.EqualValues(t, types.DayLoad{FirstLesson: 2, LastLesson: 2, LessonsNum: 1}, teacherLoads[1].Days[1])
+	}
+}
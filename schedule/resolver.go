@@ -0,0 +1,179 @@
+package schedule
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ulstu-schedule/parser/types"
+)
+
+const (
+	// minCandidateScore is the minimum score of the name to be considered as a candidate
+	minCandidateScore = 0.6
+	// minBestCandidateScore is the minimum score of the name to be resolved without asking the user
+	minBestCandidateScore = 0.9
+)
+
+// homoglyphReplacer replaces Latin letters that look like Cyrillic ones (users often type group names in
+// the wrong keyboard layout only partially).
+var homoglyphReplacer = strings.NewReplacer(
+	"a", "а", "b", "в", "c", "с", "e", "е", "h", "н", "k", "к", "m", "м", "o", "о", "p", "р", "t", "т", "x", "х",
+	"y", "у", "ё", "е",
+)
+
+// Resolver finds the group or teacher names that match the name typed by the user.
+type Resolver struct {
+	typeSchedule types.ScheduleType
+	names        []string
+	normalized   []string
+}
+
+// NewResolver returns *Resolver over the group or teacher names.
+func NewResolver(typeSchedule types.ScheduleType, names []string) *Resolver {
+	r := &Resolver{typeSchedule: typeSchedule, names: names, normalized: make([]string, len(names))}
+	for nameIdx, name := range names {
+		r.normalized[nameIdx] = normalizeName(name, typeSchedule)
+	}
+	return r
+}
+
+// NewGroupResolver returns *Resolver over all available group names from UlSTU site.
+func NewGroupResolver() *Resolver {
+	return NewResolver(types.Group, GetGroups())
+}
+
+// NewTeacherResolver returns *Resolver over all available teacher names from UlSTU site.
+func NewTeacherResolver() (*Resolver, error) {
+	teachers, err := GetTeachers()
+	if err != nil {
+		return nil, err
+	}
+	return NewResolver(types.Teacher, teachers), nil
+}
+
+// Resolve returns up to limit candidates for the query sorted by score in descending order. If limit is not
+// positive, all the candidates are returned.
+func (r *Resolver) Resolve(query string, limit int) []types.Candidate {
+	normalizedQuery := normalizeName(query, r.typeSchedule)
+	if normalizedQuery == "" {
+		return nil
+	}
+
+	candidates := make([]types.Candidate, 0)
+	for nameIdx, normalizedName := range r.normalized {
+		var score float64
+		if r.typeSchedule == types.Teacher {
+			score = getTeacherNameScore(normalizedQuery, normalizedName)
+		} else {
+			score = getGroupNameScore(normalizedQuery, normalizedName)
+		}
+
+		if score >= minCandidateScore {
+			candidates = append(candidates, types.Candidate{Name: r.names[nameIdx], Score: score})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates
+}
+
+// Best returns the name that matches the query if it can be chosen without asking the user: its score is high
+// enough, and it is the only candidate with such a score.
+func (r *Resolver) Best(query string) (string, bool) {
+	candidates := r.Resolve(query, 2)
+	if len(candidates) == 0 || candidates[0].Score < minBestCandidateScore {
+		return "", false
+	}
+	if len(candidates) == 2 && candidates[1].Score == candidates[0].Score {
+		return "", false
+	}
+	return candidates[0].Name, true
+}
+
+// normalizeName returns the name in lower case with Cyrillic letters instead of Latin homoglyphs. Separators are
+// removed from group names, and replaced with single spaces in teacher names ("Зенкина С.М." -> "зенкина с м").
+func normalizeName(name string, typeSchedule types.ScheduleType) string {
+	name = homoglyphReplacer.Replace(strings.ToLower(name))
+
+	isSeparator := func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+	}
+
+	if typeSchedule == types.Teacher {
+		return strings.Join(strings.FieldsFunc(name, isSeparator), " ")
+	}
+	return strings.Join(strings.FieldsFunc(name, isSeparator), "")
+}
+
+// getGroupNameScore returns the similarity of the normalized group names from 0 to 1.
+func getGroupNameScore(query, name string) float64 {
+	if query == name {
+		return 1
+	}
+
+	score := getSimilarity([]rune(query), []rune(name))
+	// the user typed the beginning of the name, e.g. only the specialty without the course
+	if strings.HasPrefix(name, query) {
+		prefixScore := 0.6 + 0.3*float64(len([]rune(query)))/float64(len([]rune(name)))
+		if prefixScore > score {
+			score = prefixScore
+		}
+	}
+	return score
+}
+
+// getTeacherNameScore returns the similarity of the normalized teacher names from 0 to 1. The first word is the
+// surname, the next words are the initials.
+func getTeacherNameScore(query, name string) float64 {
+	if query == name {
+		return 1
+	}
+
+	queryWords := strings.Fields(query)
+	nameWords := strings.Fields(name)
+	if len(nameWords) == 0 {
+		return 0
+	}
+
+	// the initials of the query must not contradict the initials of the name
+	areInitialsMatched := len(queryWords)-1 <= len(nameWords)-1
+	for wordIdx := 1; areInitialsMatched && wordIdx < len(queryWords); wordIdx++ {
+		queryInitial, nameInitial := []rune(queryWords[wordIdx]), []rune(nameWords[wordIdx])
+		areInitialsMatched = queryInitial[0] == nameInitial[0]
+	}
+
+	if queryWords[0] == nameWords[0] {
+		if !areInitialsMatched {
+			return 0.6
+		}
+		if len(queryWords) > 1 {
+			return 0.95
+		}
+		return 0.9
+	}
+
+	surnameScore := getSimilarity([]rune(queryWords[0]), []rune(nameWords[0]))
+	if areInitialsMatched {
+		return 0.85 * surnameScore
+	}
+	return 0.7 * surnameScore
+}
+
+// getSimilarity returns the similarity of the strings from 0 to 1 based on the edit distance.
+func getSimilarity(a, b []rune) float64 {
+	maxLen := len(a)
+	if len(b) > maxLen {
+		maxLen = len(b)
+	}
+	if maxLen == 0 {
+		return 1
+	}
+	return 1 - float64(getEditDistance(a, b))/float64(maxLen)
+}
@@ -0,0 +1,78 @@
+package schedule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+func TestResolverGroups(t *testing.T) {
+	r := NewResolver(types.Group, []string{"АТсд-21", "АТсд-11", "ИДбв-11", "ПГСбв-21", "ПГСбву-21"})
+
+	for _, query := range []string{"АТсд-21", "атсд21", "ATсд-21", "ATСД 21", " атсд - 21 "} {
+		t.Run(query, func(t *testing.T) {
+			candidates := r.Resolve(query, 0)
+
+			assert.NotEmpty(t, candidates)
+			assert.EqualValues(t, "АТсд-21", candidates[0].Name)
+			assert.EqualValues(t, 1, candidates[0].Score)
+
+			name, ok := r.Best(query)
+			assert.True(t, ok)
+			assert.EqualValues(t, "АТсд-21", name)
+		})
+	}
+
+	t.Run("prefix", func(t *testing.T) {
+		candidates := r.Resolve("атсд", 0)
+
+		assert.Len(t, candidates, 2)
+		_, ok := r.Best("атсд")
+		assert.False(t, ok)
+	})
+	t.Run("limit", func(t *testing.T) {
+		assert.Len(t, r.Resolve("пгсбв-21", 1), 1)
+	})
+	t.Run("nothing similar", func(t *testing.T) {
+		assert.Empty(t, r.Resolve("МОАИСбд-41", 0))
+		assert.Empty(t, r.Resolve(" - ", 0))
+	})
+}
+
+func TestResolverTeachers(t *testing.T) {
+	r := NewResolver(types.Teacher, []string{"Зенкина С М", "Зенкин А В", "Рандин А В", "Преподаватели кафедры"})
+
+	t.Run("surname", func(t *testing.T) {
+		candidates := r.Resolve("Зенкина", 0)
+
+		assert.EqualValues(t, "Зенкина С М", candidates[0].Name)
+		name, ok := r.Best("зенкина")
+		assert.True(t, ok)
+		assert.EqualValues(t, "Зенкина С М", name)
+	})
+	t.Run("initials with dots", func(t *testing.T) {
+		name, ok := r.Best("Зенкина С.М.")
+
+		assert.True(t, ok)
+		assert.EqualValues(t, "Зенкина С М", name)
+	})
+	t.Run("typo", func(t *testing.T) {
+		candidates := r.Resolve("Рондин А.В.", 0)
+
+		assert.NotEmpty(t, candidates)
+		assert.EqualValues(t, "Рандин А В", candidates[0].Name)
+	})
+	t.Run("wrong initials", func(t *testing.T) {
+		candidates := r.Resolve("Зенкина А", 0)
+
+		assert.NotEmpty(t, candidates)
+		assert.Less(t, candidates[0].Score, minBestCandidateScore)
+	})
+	t.Run("pseudo teacher", func(t *testing.T) {
+		name, ok := r.Best("преподаватели кафедры")
+
+		assert.True(t, ok)
+		assert.EqualValues(t, "Преподаватели кафедры", name)
+	})
+}
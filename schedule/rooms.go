@@ -3,7 +3,9 @@ package schedule
 import (
 	"fmt"
 	"github.com/ulstu-schedule/parser/types"
+	"sort"
 	"strings"
+	"time"
 )
 
 const (
@@ -52,3 +54,98 @@ func ConvertDayRoomScheduleToText(roomName string, daySchedule types.Day, daysAf
 
 	return result.String()
 }
+
+// AddRoomSchedules adds to the snapshot the schedules of the rooms built from the group schedules it contains. Every
+// room schedule contains all the weeks of the group schedules, even the ones in which the room has no lessons, so the
+// weeks are not shifted in the rotation. The fetch time of the room schedule is the latest fetch time of the group
+// schedules it was built from.
+func AddRoomSchedules(snapshot *types.Snapshot) {
+	roomSchedules := map[string]*types.Schedule{}
+	roomFetchedAt := map[string]time.Time{}
+	groupWeeks := map[int]types.Week{}
+
+	for entity, groupSchedule := range snapshot.Schedules {
+		if entity.Type != types.Group {
+			continue
+		}
+
+		for _, groupWeek := range groupSchedule.Weeks {
+			if _, ok := groupWeeks[groupWeek.Number]; !ok {
+				groupWeeks[groupWeek.Number] = groupWeek
+			}
+
+			for dayIdx, groupDay := range groupWeek.Days {
+				for lessonIdx, groupLesson := range groupDay.Lessons {
+					for _, subLesson := range groupLesson.SubLessons {
+						if subLesson.Room == "" {
+							continue
+						}
+
+						roomSchedule, ok := roomSchedules[subLesson.Room]
+						if !ok {
+							roomSchedule = &types.Schedule{}
+							roomSchedules[subLesson.Room] = roomSchedule
+						}
+						if roomFetchedAt[subLesson.Room].Before(snapshot.FetchedAt[entity]) {
+							roomFetchedAt[subLesson.Room] = snapshot.FetchedAt[entity]
+						}
+
+						roomDay := &getRoomWeek(roomSchedule, groupWeek).Days[dayIdx]
+						roomDay.WeekNumber = groupDay.WeekNumber
+						for len(roomDay.Lessons) <= lessonIdx {
+							roomDay.Lessons = append(roomDay.Lessons, types.Lesson{})
+						}
+						roomDay.Lessons[lessonIdx].SubLessons = append(roomDay.Lessons[lessonIdx].SubLessons, subLesson)
+					}
+				}
+			}
+		}
+	}
+
+	for room, roomSchedule := range roomSchedules {
+		for _, groupWeek := range groupWeeks {
+			getRoomWeek(roomSchedule, groupWeek)
+		}
+		sort.Slice(roomSchedule.Weeks, func(i, j int) bool {
+			return roomSchedule.Weeks[i].Number < roomSchedule.Weeks[j].Number
+		})
+		snapshot.Add(types.Entity{Type: types.Room, Name: room}, roomSchedule, roomFetchedAt[room])
+	}
+}
+
+// getRoomWeek returns the week of the room schedule with the number of the group week, the week is added if the room
+// schedule does not contain it.
+func getRoomWeek(roomSchedule *types.Schedule, groupWeek types.Week) *types.Week {
+	for weekIdx := range roomSchedule.Weeks {
+		if roomSchedule.Weeks[weekIdx].Number == groupWeek.Number {
+			return &roomSchedule.Weeks[weekIdx]
+		}
+	}
+
+	roomWeek := types.Week{Number: groupWeek.Number, DateStart: groupWeek.DateStart, DateEnd: groupWeek.DateEnd}
+	for dayIdx := range roomWeek.Days {
+		roomWeek.Days[dayIdx].Lessons = make([]types.Lesson, len(types.DefaultTimeTable.Slots))
+	}
+	roomSchedule.Weeks = append(roomSchedule.Weeks, roomWeek)
+	return &roomSchedule.Weeks[len(roomSchedule.Weeks)-1]
+}
+
+// FindFreeRooms returns the sorted names of the rooms that have no lessons in the time slot with the index lessonIdx
+// on the date. roomSchedules maps the names of the rooms to their schedules. The rooms with no lessons in the week of
+// the date are free. The rooms which schedules have no weeks are skipped, because it is unknown whether they are free.
+func FindFreeRooms(roomSchedules map[string]*types.Schedule, date time.Time, lessonIdx int) []string {
+	freeRooms := make([]string, 0)
+	for room, roomSchedule := range roomSchedules {
+		day, ok := getScheduleDayByDate(roomSchedule, date)
+		if !ok {
+			continue
+		}
+
+		if lessonIdx >= len(day.Lessons) || len(day.Lessons[lessonIdx].SubLessons) == 0 {
+			freeRooms = append(freeRooms, room)
+		}
+	}
+
+	sort.Strings(freeRooms)
+	return freeRooms
+}
@@ -1,11 +1,14 @@
 package schedule
 
 import (
+	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/ulstu-schedule/parser/mock"
+	"github.com/ulstu-schedule/parser/types"
 	"regexp"
 	"testing"
+	"time"
 )
 
 func TestConvertDayRoomScheduleToText(t *testing.T) {
@@ -38,3 +41,85 @@ func TestConvertDayRoomScheduleToText(t *testing.T) {
 		assert.EqualValues(t, true, findStart.MatchString(result))
 	})
 }
+
+func TestAddRoomSchedules(t *testing.T) {
+	groupSchedule := mock.TestGroupSchedule(t)
+	fetchedAt := time.Date(2024, 4, 16, 10, 0, 0, 0, time.UTC)
+
+	snapshot := types.NewSnapshot()
+	snapshot.Add(types.Entity{Type: types.Group, Name: "АТсд-21"}, groupSchedule, fetchedAt)
+	snapshot.Add(types.Entity{Type: types.Teacher, Name: "Зенкина С М"}, mock.TestTeacherSchedule(t), fetchedAt)
+
+	AddRoomSchedules(snapshot)
+
+	roomEntity := types.Entity{Type: types.Room, Name: "6-002(2)"}
+	roomSchedule, ok := snapshot.Schedules[roomEntity]
+	assert.True(t, ok)
+	assert.EqualValues(t, fetchedAt, snapshot.FetchedAt[roomEntity])
+	assert.Len(t, roomSchedule.Weeks, 2)
+	assert.EqualValues(t, 11, roomSchedule.Weeks[0].Number)
+
+	subLessons := roomSchedule.Weeks[0].Days[4].Lessons[0].SubLessons
+	assert.Len(t, subLessons, 1)
+	assert.EqualValues(t, "Сопротивление материалов", subLessons[0].Name)
+	assert.EqualValues(t, "АТсд-21", subLessons[0].Group)
+
+	// the room used only in the 12th week has the empty 11th week, so the lessons are not shifted to it
+	roomSchedule = snapshot.Schedules[types.Entity{Type: types.Room, Name: "6-003"}]
+	if assert.Len(t, roomSchedule.Weeks, 2) {
+		assert.EqualValues(t, 11, roomSchedule.Weeks[0].Number)
+		assert.True(t, IsWeekScheduleEmpty(roomSchedule.Weeks[0]))
+		assert.EqualValues(t, groupSchedule.Weeks[0].DateStart, roomSchedule.Weeks[0].DateStart)
+		assert.EqualValues(t, 12, roomSchedule.Weeks[1].Number)
+	}
+
+	_, err := ParseWeekScheduleByDate(roomSchedule, "6-003", time.Date(2024, 4, 16, 12, 0, 0, 0, time.UTC))
+	assert.True(t, errors.Is(err, types.ErrNotPublished))
+
+	day, err := ParseDayScheduleByDate(roomSchedule, "6-003", time.Date(2024, 4, 23, 12, 0, 0, 0, time.UTC))
+	if assert.NoError(t, err) {
+		assert.Len(t, day.Lessons[1].SubLessons, 1)
+	}
+
+	// rooms used only in the teacher schedules are not added
+	_, ok = snapshot.Schedules[types.Entity{Type: types.Room, Name: "5-ДОТ"}]
+	assert.False(t, ok)
+}
+
+func TestFindFreeRooms(t *testing.T) {
+	snapshot := types.NewSnapshot()
+	snapshot.Add(types.Entity{Type: types.Group, Name: "АТсд-21"}, mock.TestGroupSchedule(t), time.Now())
+	AddRoomSchedules(snapshot)
+
+	roomSchedules := map[string]*types.Schedule{"6-000": {}}
+	for entity, roomSchedule := range snapshot.Schedules {
+		if entity.Type == types.Room && (entity.Name == "6-002(2)" || entity.Name == "6-419") {
+			roomSchedules[entity.Name] = roomSchedule
+		}
+	}
+	assert.Len(t, roomSchedules, 3)
+
+	date := time.Date(2024, 4, 19, 12, 0, 0, 0, time.UTC)
+	assert.EqualValues(t, []string{"6-419"}, FindFreeRooms(roomSchedules, date, 0))
+	assert.EqualValues(t, []string{"6-002(2)", "6-419"}, FindFreeRooms(roomSchedules, date, 7))
+
+	t.Run("room used in one of two weeks", func(t *testing.T) {
+		// 6-003 has lessons only in the 12th week, 6-419 - only in the 11th one
+		roomSchedules := map[string]*types.Schedule{
+			"6-003": snapshot.Schedules[types.Entity{Type: types.Room, Name: "6-003"}],
+			"6-419": snapshot.Schedules[types.Entity{Type: types.Room, Name: "6-419"}],
+		}
+
+		tuesday11 := time.Date(2024, 4, 16, 12, 0, 0, 0, time.UTC)
+		assert.EqualValues(t, []string{"6-003", "6-419"}, FindFreeRooms(roomSchedules, tuesday11, 1))
+		tuesday12 := time.Date(2024, 4, 23, 12, 0, 0, 0, time.UTC)
+		assert.EqualValues(t, []string{"6-419"}, FindFreeRooms(roomSchedules, tuesday12, 1))
+
+		thursday11 := time.Date(2024, 4, 18, 12, 0, 0, 0, time.UTC)
+		assert.EqualValues(t, []string{"6-003"}, FindFreeRooms(roomSchedules, thursday11, 3))
+		thursday12 := time.Date(2024, 4, 25, 12, 0, 0, 0, time.UTC)
+		assert.EqualValues(t, []string{"6-003", "6-419"}, FindFreeRooms(roomSchedules, thursday12, 3))
+		// the week after the published ones is chosen by the rotation
+		assert.EqualValues(t, []string{"6-003"}, FindFreeRooms(roomSchedules, thursday11.AddDate(0, 0, 14), 3))
+	})
+}
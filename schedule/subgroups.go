@@ -0,0 +1,44 @@
+package schedule
+
+import "github.com/ulstu-schedule/parser/types"
+
+// FilterSubGroup returns the copy of the group schedule that contains only the SubLessons of the subgroup and the
+// SubLessons common for the whole group. The schedule itself is not changed. If subGroup is 0, the copy contains all
+// the SubLessons.
+func FilterSubGroup(schedule *types.Schedule, subGroup int) *types.Schedule {
+	filtered := &types.Schedule{Weeks: make([]types.Week, len(schedule.Weeks))}
+	for weekIdx := range schedule.Weeks {
+		filtered.Weeks[weekIdx] = *FilterWeekSubGroup(&schedule.Weeks[weekIdx], subGroup)
+	}
+	return filtered
+}
+
+// FilterWeekSubGroup returns the copy of the group week schedule that contains only the SubLessons of the subgroup
+// and the SubLessons common for the whole group.
+func FilterWeekSubGroup(week *types.Week, subGroup int) *types.Week {
+	filtered := *week
+	for dayIdx := range week.Days {
+		filtered.Days[dayIdx] = *FilterDaySubGroup(&week.Days[dayIdx], subGroup)
+	}
+	return &filtered
+}
+
+// FilterDaySubGroup returns the copy of the group day schedule that contains only the SubLessons of the subgroup and
+// the SubLessons common for the whole group. The Lessons left without SubLessons are kept empty, so the indexes of
+// the Lessons still match their time slots.
+func FilterDaySubGroup(day *types.Day, subGroup int) *types.Day {
+	filtered := &types.Day{WeekNumber: day.WeekNumber}
+	if day.Lessons == nil {
+		return filtered
+	}
+
+	filtered.Lessons = make([]types.Lesson, len(day.Lessons))
+	for lessonIdx, lesson := range day.Lessons {
+		for _, subLesson := range lesson.SubLessons {
+			if subGroup == 0 || subLesson.SubGroupNumber() == 0 || subLesson.SubGroupNumber() == subGroup {
+				filtered.Lessons[lessonIdx].SubLessons = append(filtered.Lessons[lessonIdx].SubLessons, subLesson)
+			}
+		}
+	}
+	return filtered
+}
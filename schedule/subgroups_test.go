@@ -0,0 +1,42 @@
+package schedule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/mock"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+func TestFilterSubGroup(t *testing.T) {
+	groupSchedule := mock.TestGroupSchedule(t)
+
+	filtered := FilterSubGroup(groupSchedule, 1)
+	if assert.Len(t, filtered.Weeks, 2) {
+		assert.EqualValues(t, groupSchedule.Weeks[0].Number, filtered.Weeks[0].Number)
+		assert.EqualValues(t, groupSchedule.Weeks[0].DateStart, filtered.Weeks[0].DateStart)
+
+		monday := filtered.Weeks[0].Days[0]
+		assert.Len(t, monday.Lessons, 8)
+		assert.Len(t, monday.Lessons[1].SubLessons, 1) // 1 п/г
+		assert.Empty(t, monday.Lessons[2].SubLessons)  // 2 п/г
+
+		thursday := filtered.Weeks[0].Days[3]
+		assert.Len(t, thursday.Lessons[0].SubLessons, 1) // 1 п/г
+		assert.Len(t, thursday.Lessons[3].SubLessons, 1) // common lesson
+		assert.Empty(t, thursday.Lessons[4].SubLessons)  // 3 п/г
+	}
+
+	// the schedule itself is not changed
+	assert.EqualValues(t, mock.TestGroupSchedule(t), groupSchedule)
+
+	day := &types.Day{Lessons: []types.Lesson{{SubLessons: []types.SubLesson{
+		{Name: "Иностранный язык", SubGroup: "2 п/г", SubGroupNum: 2},
+		{Name: "Компьютерная графика", SubGroupNum: 3},
+		{Name: "Философия"},
+	}}}}
+	assert.EqualValues(t, []types.SubLesson{{Name: "Философия"}}, FilterDaySubGroup(day, 1).Lessons[0].SubLessons)
+	assert.EqualValues(t, []types.SubLesson{{Name: "Компьютерная графика", SubGroupNum: 3}, {Name: "Философия"}},
+		FilterDaySubGroup(day, 3).Lessons[0].SubLessons)
+	assert.Len(t, FilterDaySubGroup(day, 0).Lessons[0].SubLessons, 3)
+}
@@ -0,0 +1,260 @@
+package schedule
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+const (
+	weekNumberPattern = `:\s*(\d+)`
+	pairNumberPattern = `^(\d+)\s*(-?\s*[а-я]{1,2})?\s*(пара)?$`
+)
+
+var (
+	findWeekNumber = regexp.MustCompile(weekNumberPattern)
+	findPairNumber = regexp.MustCompile(pairNumberPattern)
+
+	// weekDayPrefixes contains the prefixes of the day labels in the schedule table (e.g. "Пнд" or "Понедельник")
+	weekDayPrefixes = [7][]string{{"пн", "пон"}, {"вт"}, {"ср"}, {"чт", "чет"}, {"пт", "пят"}, {"сб", "суб"},
+		{"вс", "вос"}}
+)
+
+// scheduleTable represents the located parts of the HTML table with the week schedule.
+type scheduleTable struct {
+	weekNumber int
+	// pairColumns maps the index of the cell in the row to the index of the lesson
+	pairColumns map[int]int
+	lessonsNum  int
+	dayRows     [7]*goquery.Selection
+	dayRowIdxs  [7]int
+}
+
+// parseFullSchedule returns the full schedule parsed from the document of the schedule page. Every week on the page
+// is a heading paragraph with the week number followed by the table, where the header row contains the pair numbers
+// and every next row is a day. The problems found in the table cells are added to diag.
+func parseFullSchedule(doc *goquery.Document, name string, typeSchedule types.ScheduleType,
+	diag *diagnostics) (*types.Schedule, error) {
+	schedule := &types.Schedule{}
+
+	var (
+		heading  string
+		tableIdx int
+		err      error
+	)
+
+	doc.Find("p, table").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		// paragraphs and tables nested in the schedule table are handled while parsing the table
+		if s.ParentsFiltered("table").Length() > 0 {
+			return true
+		}
+
+		if goquery.NodeName(s) == "p" {
+			heading = s.Text()
+			return true
+		}
+
+		table, tableErr := locateScheduleTable(s, tableIdx, heading)
+		tableIdx++
+		if tableErr != nil {
+			err = tableErr
+			return false
+		}
+		// the table does not look like a schedule table (e.g. the legend)
+		if table == nil {
+			return true
+		}
+
+		schedule.Weeks = append(schedule.Weeks, parseWeekTable(table, len(schedule.Weeks), name, typeSchedule, diag))
+		heading = ""
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(schedule.Weeks) == 0 {
+		return nil, &types.LayoutError{Table: -1, Row: -1, Reason: "no schedule table found"}
+	}
+
+	return schedule, nil
+}
+
+// locateScheduleTable finds the header row, the pair columns and the day rows of the table. Returns nil if the table
+// has no header row with the pair numbers.
+func locateScheduleTable(tableS *goquery.Selection, tableIdx int, heading string) (*scheduleTable, error) {
+	table := &scheduleTable{pairColumns: map[int]int{}}
+
+	rows := tableS.Find("tr").FilterFunction(func(_ int, rowS *goquery.Selection) bool {
+		return rowS.Closest("table").IsSelection(tableS)
+	})
+
+	headerRowIdx := -1
+	rows.EachWithBreak(func(rowIdx int, rowS *goquery.Selection) bool {
+		if headerRowIdx == -1 {
+			if isHeaderRow(rowS) {
+				headerRowIdx = rowIdx
+				locatePairColumns(table, rowS)
+			}
+			return true
+		}
+
+		cells := rowS.ChildrenFiltered("td, th")
+		weekDayNum := getWeekDayNumByLabel(cells.First().Text())
+		if weekDayNum != -1 && table.dayRows[weekDayNum] == nil {
+			table.dayRows[weekDayNum] = rowS
+			table.dayRowIdxs[weekDayNum] = rowIdx
+		}
+		return true
+	})
+
+	if headerRowIdx == -1 {
+		return nil, nil
+	}
+
+	if len(table.pairColumns) == 0 {
+		return nil, &types.LayoutError{Table: tableIdx, Row: headerRowIdx, Reason: "no pair columns in the header row"}
+	}
+
+	hasDays := false
+	for dayIdx, dayRow := range table.dayRows {
+		if dayRow == nil {
+			continue
+		}
+		hasDays = true
+
+		cellsNum := dayRow.ChildrenFiltered("td, th").Length()
+		for cellIdx := range table.pairColumns {
+			if cellIdx >= cellsNum {
+				return nil, &types.LayoutError{Table: tableIdx, Row: table.dayRowIdxs[dayIdx],
+					Reason: "the day row is shorter than the header row"}
+			}
+		}
+	}
+
+	if !hasDays {
+		return nil, &types.LayoutError{Table: tableIdx, Row: -1, Reason: "no day rows"}
+	}
+
+	weekNumbers := findWeekNumber.FindAllStringSubmatch(heading, -1)
+	if len(weekNumbers) == 0 {
+		return nil, &types.LayoutError{Table: tableIdx, Row: -1, Reason: "no week number in the heading"}
+	}
+	table.weekNumber, _ = strconv.Atoi(weekNumbers[len(weekNumbers)-1][1])
+
+	return table, nil
+}
+
+// isHeaderRow returns true if the row is the header row of the schedule table, otherwise - false.
+func isHeaderRow(rowS *goquery.Selection) bool {
+	cells := rowS.ChildrenFiltered("td, th")
+	if strings.Contains(strings.ToLower(cells.First().Text()), "пара") {
+		return true
+	}
+
+	pairCellsNum := 0
+	cells.Each(func(cellIdx int, cellS *goquery.Selection) {
+		if cellIdx > 0 && findPairNumber.MatchString(normalizeCellText(cellS.Text())) {
+			pairCellsNum++
+		}
+	})
+	return pairCellsNum > 0 && pairCellsNum == cells.Length()-1
+}
+
+// locatePairColumns fills the pair columns of the table based on the pair numbers in the header row.
+func locatePairColumns(table *scheduleTable, headerRowS *goquery.Selection) {
+	headerRowS.ChildrenFiltered("td, th").Each(func(cellIdx int, cellS *goquery.Selection) {
+		if cellIdx == 0 {
+			return
+		}
+
+		pairNumber := findPairNumber.FindStringSubmatch(normalizeCellText(cellS.Text()))
+		if pairNumber == nil {
+			return
+		}
+
+		lessonIdx, _ := strconv.Atoi(pairNumber[1])
+		lessonIdx--
+		if lessonIdx < 0 {
+			return
+		}
+
+		table.pairColumns[cellIdx] = lessonIdx
+		if lessonIdx+1 > table.lessonsNum {
+			table.lessonsNum = lessonIdx + 1
+		}
+	})
+}
+
+// parseWeekTable returns types.Week with the lessons from the located schedule table.
+func parseWeekTable(table *scheduleTable, weekIdx int, name string, typeSchedule types.ScheduleType,
+	diag *diagnostics) types.Week {
+	week := types.Week{Number: table.weekNumber}
+
+	isDateFound := false
+	for dayIdx, dayRow := range table.dayRows {
+		week.Days[dayIdx].Lessons = make([]types.Lesson, table.lessonsNum)
+		if dayRow == nil {
+			continue
+		}
+		week.Days[dayIdx].WeekNumber = table.weekNumber
+
+		cells := dayRow.ChildrenFiltered("td, th")
+		if !isDateFound {
+			isDateFound = true
+			dateStartWeek, dateEndWeek := parseDateScheduleWeek(findStartDayWeek, cells.First())
+			week.DateStart = dateStartWeek.AddDate(0, 0, -dayIdx)
+			week.DateEnd = dateEndWeek.AddDate(0, 0, -dayIdx)
+		}
+
+		for _, cellIdx := range table.getPairCellIdxs() {
+			lessonIdx := table.pairColumns[cellIdx]
+			cellS := cells.Eq(cellIdx)
+			warn := diag.cellWarner(&week, weekIdx, dayIdx, lessonIdx, cellS)
+			if typeSchedule == types.Group {
+				week.Days[dayIdx].Lessons[lessonIdx] = *parseGroupLesson(name, lessonIdx,
+					findTeacherAndRoom, findTeacher, findRoom, findSubGroup, cellS, warn)
+			}
+			if typeSchedule == types.Teacher {
+				week.Days[dayIdx].Lessons[lessonIdx] = *parseTeacherLesson(name, lessonIdx, cellS, warn)
+			}
+		}
+	}
+
+	return week
+}
+
+// getPairCellIdxs returns the indexes of the pair cells in the row in ascending order, so the cells are parsed and
+// warned about in the same order as on the page.
+func (t *scheduleTable) getPairCellIdxs() []int {
+	cellIdxs := make([]int, 0, len(t.pairColumns))
+	for cellIdx := range t.pairColumns {
+		cellIdxs = append(cellIdxs, cellIdx)
+	}
+	sort.Ints(cellIdxs)
+	return cellIdxs
+}
+
+// getWeekDayNumByLabel returns the number of the day of the week by the label of the day row, or -1 if the label is
+// not a day of the week.
+func getWeekDayNumByLabel(label string) int {
+	label = normalizeCellText(label)
+	for weekDayNum, prefixes := range weekDayPrefixes {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(label, prefix) {
+				return weekDayNum
+			}
+		}
+	}
+	return -1
+}
+
+// normalizeCellText returns the text of the table cell in lower case without extra spaces.
+func normalizeCellText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
@@ -0,0 +1,123 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+var testWeekDayLabels = [6]string{"Пнд", "Втр", "Срд", "Чтв", "Птн", "Сбт"}
+
+// testScheduleTableHTML returns the HTML of the week schedule table. lessons maps "day:pair" to the cell content,
+// extraColumn inserts the column that does not contain lessons after the day labels.
+func testScheduleTableHTML(weekNumber int, lessons map[string]string, extraColumn bool) string {
+	sb := &strings.Builder{}
+	_, _ = fmt.Fprintf(sb, "<p><font>Расписание группы АТсд-21</font><b><font>Неделя: %d-я</font></b></p>", weekNumber)
+	sb.WriteString("<table>")
+
+	sb.WriteString("<tr><td><p><font>Пара</font></p></td>")
+	if extraColumn {
+		sb.WriteString("<td><p><font>Примечание</font></p></td>")
+	}
+	for pair := 1; pair <= 8; pair++ {
+		_, _ = fmt.Fprintf(sb, "<td><p><font>%d-я</font></p></td>", pair)
+	}
+	sb.WriteString("</tr>")
+
+	sb.WriteString("<tr><td><p><font>Время</font></p></td>")
+	if extraColumn {
+		sb.WriteString("<td><p></p></td>")
+	}
+	for _, slot := range types.DefaultTimeTable.Slots {
+		_, _ = fmt.Fprintf(sb, "<td><p><font>%s</font></p></td>", slot)
+	}
+	sb.WriteString("</tr>")
+
+	for dayIdx, label := range testWeekDayLabels {
+		_, _ = fmt.Fprintf(sb, "<tr><td><p><font><b>%s %d</b></font></p></td>", label, 15+dayIdx)
+		if extraColumn {
+			sb.WriteString("<td><p></p></td>")
+		}
+		for pair := 1; pair <= 8; pair++ {
+			_, _ = fmt.Fprintf(sb, "<td><p><font>%s</font></p></td>", lessons[fmt.Sprintf("%d:%d", dayIdx, pair)])
+		}
+		sb.WriteString("</tr>")
+	}
+
+	sb.WriteString("</table>")
+	return sb.String()
+}
+
+func testScheduleDoc(t *testing.T, pageHTML string) *goquery.Document {
+	t.Helper()
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader("<html><body>" + pageHTML + "</body></html>"))
+	assert.NoError(t, err)
+	return doc
+}
+
+func TestParseFullSchedule(t *testing.T) {
+	lessons := map[string]string{
+		"0:4": "лек.Философия <br>Розанов Ф И 6-419 <br>",
+		"3:2": "пр.Иностранный язык <br>Ларнер Э А 6-516 <br>",
+		"4:3": "лаб.Компьютерная графика - 2 п/г <br>Рандин А В 6-416 <br>",
+	}
+
+	t.Run("two weeks", func(t *testing.T) {
+		doc := testScheduleDoc(t, testScheduleTableHTML(11, lessons, false)+testScheduleTableHTML(12, nil, false))
+
+		schedule, err := parseFullSchedule(doc, "АТсд-21", types.Group, nil)
+		assert.NoError(t, err)
+		assert.Len(t, schedule.Weeks, 2)
+		assert.EqualValues(t, 11, schedule.Weeks[0].Number)
+		assert.EqualValues(t, 12, schedule.Weeks[1].Number)
+
+		subLessons := schedule.Weeks[0].Days[0].Lessons[3].SubLessons
+		assert.Len(t, subLessons, 1)
+		assert.EqualValues(t, "Философия", subLessons[0].Name)
+		assert.EqualValues(t, "Розанов Ф И", subLessons[0].Teacher)
+		assert.EqualValues(t, "6-419", subLessons[0].Room)
+		assert.EqualValues(t, types.Lecture, subLessons[0].Type)
+
+		assert.Len(t, schedule.Weeks[0].Days[3].Lessons[1].SubLessons, 1)
+
+		subLessons = schedule.Weeks[0].Days[4].Lessons[2].SubLessons
+		if assert.Len(t, subLessons, 1) {
+			assert.EqualValues(t, "2 п/г", subLessons[0].SubGroup)
+			assert.EqualValues(t, 2, subLessons[0].SubGroupNum)
+			assert.EqualValues(t, 0, schedule.Weeks[0].Days[0].Lessons[3].SubLessons[0].SubGroupNum)
+		}
+
+		assert.True(t, IsWeekScheduleEmpty(schedule.Weeks[1]))
+	})
+	t.Run("extra column", func(t *testing.T) {
+		doc := testScheduleDoc(t, testScheduleTableHTML(11, lessons, true))
+
+		schedule, err := parseFullSchedule(doc, "АТсд-21", types.Group, nil)
+		assert.NoError(t, err)
+		assert.Len(t, schedule.Weeks[0].Days[0].Lessons, 8)
+		assert.EqualValues(t, "Философия", schedule.Weeks[0].Days[0].Lessons[3].SubLessons[0].Name)
+	})
+	t.Run("no schedule table", func(t *testing.T) {
+		doc := testScheduleDoc(t, "<p>Расписание не опубликовано</p>")
+
+		_, err := parseFullSchedule(doc, "АТсд-21", types.Group, nil)
+
+		var layoutErr *types.LayoutError
+		assert.True(t, errors.As(err, &layoutErr))
+	})
+	t.Run("no day rows", func(t *testing.T) {
+		doc := testScheduleDoc(t, "<p>Неделя: 11-я</p><table><tr><td>Пара</td><td>1-я</td></tr></table>")
+
+		_, err := parseFullSchedule(doc, "АТсд-21", types.Group, nil)
+
+		var layoutErr *types.LayoutError
+		assert.True(t, errors.As(err, &layoutErr))
+		assert.EqualValues(t, 0, layoutErr.Table)
+	})
+}
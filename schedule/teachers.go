@@ -6,6 +6,7 @@ import (
 	"github.com/PuerkitoBio/goquery"
 	"github.com/fogleman/gg"
 	"github.com/ulstu-schedule/parser/types"
+	"regexp"
 	"strings"
 	"time"
 )
@@ -15,32 +16,127 @@ const (
 	headingTableTeacherFontSize = 38
 )
 
+var (
+	findPseudoTeacher = regexp.MustCompile(fmt.Sprintf(`^(%s)$`, pseudoTeacherPattern))
+
+	// teacherPositions contains the positions (in lower case) that can be listed next to the teacher name
+	teacherPositions = []string{"профессор", "доцент", "преподаватель", "ассистент", "зав", "декан"}
+)
+
 // GetFullTeacherSchedule returns the full teacher's schedule.
 func GetFullTeacherSchedule(teacher string) (*types.Schedule, error) {
 	teacherURL, err := getTeacherURL(teacher)
 	if err != nil {
 		return nil, err
 	}
-	return getFullSchedule(teacher, teacherURL, types.Teacher)
+	return getFullSchedule(teacher, teacherURL, types.Teacher, nil)
+}
+
+// GetFullTeacherScheduleWithDiagnostics returns the full teacher's schedule and the warnings about the table cells
+// that were not parsed or were parsed with assumptions.
+func GetFullTeacherScheduleWithDiagnostics(teacher string) (*types.Schedule, []types.ParseWarning, error) {
+	teacherURL, err := getTeacherURL(teacher)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	diag := &diagnostics{}
+	schedule, err := getFullSchedule(teacher, teacherURL, types.Teacher, diag)
+	return schedule, diag.warnings, err
 }
 
+// GetTeachers returns all available teacher names from UlSTU site.
 func GetTeachers() ([]string, error) {
-	teachers := make([]string, 0, 800)
+	teachersInfo, err := GetTeachersInfo()
+	if err != nil {
+		return nil, err
+	}
 
-	doc, err := getDocFromURL(fmt.Sprintf(teacherScheduleURL, "Praspisan.html"))
+	teachers := make([]string, 0, len(teachersInfo))
+	for _, teacherInfo := range teachersInfo {
+		teachers = append(teachers, teacherInfo.Name)
+	}
+	return teachers, nil
+}
+
+// GetTeachersInfo returns all available teachers with the information from the list of teachers on UlSTU site.
+func GetTeachersInfo() (_ []types.TeacherInfo, err error) {
+	pageURL := fmt.Sprintf(teacherScheduleURL, "Praspisan.html")
+	defer recoverParseError(&err, &pageURL, "", "teacher list")
+
+	doc, err := getDocFromURL(pageURL)
 	if err != nil {
 		return nil, err
 	}
 
+	return parseTeacherList(doc), nil
+}
+
+// GetTeachersByDepartment returns the teachers whose department contains the department name (case-insensitive).
+func GetTeachersByDepartment(teachers []types.TeacherInfo, department string) []types.TeacherInfo {
+	department = strings.ToLower(strings.TrimSpace(department))
+
+	departmentTeachers := make([]types.TeacherInfo, 0)
+	for _, teacherInfo := range teachers {
+		if teacherInfo.Department != "" && strings.Contains(strings.ToLower(teacherInfo.Department), department) {
+			departmentTeachers = append(departmentTeachers, teacherInfo)
+		}
+	}
+	return departmentTeachers
+}
+
+// parseTeacherList returns the teachers from the list of teachers. Each cell of the list contains the short name
+// of the teacher followed by the comma-separated details (department, position).
+func parseTeacherList(doc *goquery.Document) []types.TeacherInfo {
+	teachers := make([]types.TeacherInfo, 0, 800)
+
 	doc.Find("td").Each(func(i int, s *goquery.Selection) {
-		if i > 0 {
-			foundTeacherName := s.Find("font").Text()
-			formattedTeacherName := strings.Split(foundTeacherName, ",")[0]
-			teachers = append(teachers, formattedTeacherName)
+		// the first cell is the heading of the list
+		if i == 0 {
+			return
+		}
+
+		cellParts := strings.Split(s.Find("font").Text(), ",")
+		teacherInfo := types.TeacherInfo{Name: strings.TrimSpace(cellParts[0])}
+		if teacherInfo.Name == "" {
+			return
 		}
+
+		for _, detail := range cellParts[1:] {
+			detail = strings.TrimSpace(detail)
+			if detail == "" {
+				continue
+			}
+			teacherInfo.Details = append(teacherInfo.Details, detail)
+
+			lowerDetail := strings.ToLower(detail)
+			switch {
+			case teacherInfo.Department == "" && strings.HasPrefix(lowerDetail, "каф"):
+				teacherInfo.Department = detail
+			case teacherInfo.Position == "" && isTeacherPosition(lowerDetail):
+				teacherInfo.Position = detail
+			}
+		}
+
+		teacherInfo.IsPseudo = findPseudoTeacher.MatchString(teacherInfo.Name)
+		if href, ok := s.Find("a").Attr("href"); ok {
+			teacherInfo.URL = fmt.Sprintf(teacherScheduleURL, href)
+		}
+
+		teachers = append(teachers, teacherInfo)
 	})
 
-	return teachers, nil
+	return teachers
+}
+
+// isTeacherPosition returns true if the detail from the list of teachers is the position, otherwise - false.
+func isTeacherPosition(lowerDetail string) bool {
+	for _, position := range teacherPositions {
+		if strings.Contains(lowerDetail, position) {
+			return true
+		}
+	}
+	return false
 }
 
 // GetTextDayTeacherSchedule returns a text representation of the day schedule.
@@ -164,17 +260,24 @@ func ConvertDayTeacherScheduleToText(teacherName string, daySchedule types.Day,
 }
 
 // parseTeacherLesson returns *types.Lesson received from the HTML document.
-func parseTeacherLesson(teacher string, lessonIdx int, s *goquery.Selection) *types.Lesson {
+func parseTeacherLesson(teacher string, lessonIdx int, s *goquery.Selection, warn warnFunc) *types.Lesson {
 	lesson := types.Lesson{}
 	tableCellHTML, _ := s.Find("font").Html()
 	// if the table cell contains the lesson info
 	if !strings.HasPrefix(tableCellHTML, "_") && tableCellHTML != "" {
 		// <br/> separates the name of the lesson, the groups and the audience number
 		splitLessonInfoHTML := strings.Split(tableCellHTML, "<br/>")
+		if len(splitLessonInfoHTML) < 3 {
+			warn(types.IncompleteLessonInfo, tableCellHTML)
+			return &lesson
+		}
 		lessonGroups := strings.Split(splitLessonInfoHTML[0], ",")
 		lesson.SubLessons = make([]types.SubLesson, 0, len(lessonGroups))
 		lessonTypeAndName := strings.Split(splitLessonInfoHTML[1], ".")
 		lessonType := determineLessonType(lessonTypeAndName[0])
+		if lessonType == types.Unknown {
+			warn(types.UnknownLessonTypeFragment, splitLessonInfoHTML[1])
+		}
 		r := strings.NewReplacer(",", ", ", ".", ". ", "- ", " - ", " -", " - ") // TODO: вынести в самый верх, чтобы постоянно его не создавать (см. пример groups.go)
 		lessonName := r.Replace(strings.TrimSpace(lessonTypeAndName[len(lessonTypeAndName)-1]))
 		for _, groupName := range lessonGroups {
@@ -194,36 +297,43 @@ func parseTeacherLesson(teacher string, lessonIdx int, s *goquery.Selection) *ty
 	return &lesson
 }
 
-// getTeacherURL returns the url to the teacher's schedule on UlSTU site.
-func getTeacherURL(teacherName string) (string, error) {
+// getTeacherURL returns the url to the teacher's schedule on UlSTU site. Returns *types.NotFoundError if there is no
+// such teacher in the list of teachers.
+func getTeacherURL(teacherName string) (_ string, err error) {
 	teacherURL := ""
 
-	doc, err := getDocFromURL(fmt.Sprintf(teacherScheduleURL, "Praspisan.html"))
+	pageURL := fmt.Sprintf(teacherScheduleURL, "Praspisan.html")
+	defer recoverParseError(&err, &pageURL, teacherName, "teacher list")
+
+	doc, err := getDocFromURL(pageURL)
 	if err != nil {
 		return "", err
 	}
 
-	doc.Find("td").EachWithBreak(func(i int, s *goquery.Selection) bool {
-		foundTeacherName := s.Find("font").Text()
-		formattedTeacherName := strings.Split(foundTeacherName, ",")[0]
-		if formattedTeacherName == teacherName {
-			url, _ := s.Find("a").Attr("href")
-			teacherURL = fmt.Sprintf(teacherScheduleURL, url)
-			return false
-		}
-		return true
-	})
+	// all the found teacher names are used to suggest the nearest names if the teacher is not found
+	teacherNames := make([]string, 0, 800)
 
-	if teacherURL != "" {
-		doc, err = getDocFromURL(teacherURL)
-		if err != nil {
-			return "", err
+	for _, teacherInfo := range parseTeacherList(doc) {
+		teacherNames = append(teacherNames, teacherInfo.Name)
+		if teacherInfo.Name == teacherName {
+			teacherURL = teacherInfo.URL
+			break
 		}
+	}
 
-		teacherNameFromDoc := doc.Find("p").Get(0).LastChild.FirstChild.Data
-		if !strings.Contains(teacherNameFromDoc, teacherName) {
-			return "", &types.IncorrectLinkError{Name: teacherName, NameFromURL: teacherNameFromDoc}
-		}
+	if teacherURL == "" {
+		return "", &types.NotFoundError{Name: teacherName, Type: types.Teacher,
+			Suggestions: suggestNames(teacherName, teacherNames, types.Teacher)}
+	}
+
+	pageURL = teacherURL
+	doc, err = getDocFromURL(teacherURL)
+	if err != nil {
+		return "", err
+	}
+
+	if err = checkScheduleTitle(doc, teacherName, teacherURL); err != nil {
+		return "", err
 	}
 
 	return teacherURL, nil
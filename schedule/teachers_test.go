@@ -69,3 +69,31 @@ func TestConvertDayTeacherScheduleToText(t *testing.T) {
 		assert.EqualValues(t, true, findStart.MatchString(result))
 	})
 }
+
+func TestParseTeacherList(t *testing.T) {
+	doc := testScheduleDoc(t, `<table><tr><td><font>Преподаватели</font></td></tr>`+
+		`<tr><td><a href="p1.html"><font>Зенкина С М, доцент, каф. Дизайн</font></a></td></tr>`+
+		`<tr><td><a href="p2.html"><font>Рандин А В</font></a></td></tr>`+
+		`<tr><td><a href="p3.html"><font>Преподaватели кафедры, каф. Физвоспитание</font></a></td></tr>`+
+		`<tr><td></td></tr></table>`)
+
+	teachers := parseTeacherList(doc)
+
+	assert.Len(t, teachers, 3)
+	assert.EqualValues(t, "Зенкина С М", teachers[0].Name)
+	assert.EqualValues(t, "доцент", teachers[0].Position)
+	assert.EqualValues(t, "каф. Дизайн", teachers[0].Department)
+	assert.EqualValues(t, []string{"доцент", "каф. Дизайн"}, teachers[0].Details)
+	assert.EqualValues(t, fmt.Sprintf(teacherScheduleURL, "p1.html"), teachers[0].URL)
+	assert.False(t, teachers[0].IsPseudo)
+
+	assert.Empty(t, teachers[1].Details)
+	assert.True(t, teachers[2].IsPseudo)
+
+	t.Run("by department", func(t *testing.T) {
+		departmentTeachers := GetTeachersByDepartment(teachers, "дизайн")
+
+		assert.Len(t, departmentTeachers, 1)
+		assert.EqualValues(t, "Зенкина С М", departmentTeachers[0].Name)
+	})
+}
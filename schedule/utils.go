@@ -7,6 +7,7 @@ import (
 	"image"
 	"io"
 	"log"
+	"math"
 	"math/rand"
 	"net/http"
 	"regexp"
@@ -32,8 +33,7 @@ const (
 	cellWidth  = 200
 	cellHeight = 150
 
-	maxScheduleWeekCount = 2
-	lengthScheduleTable  = 91
+	maxSuggestionsNum = 3
 )
 
 //go:embed assets/week_schedule_teacher_template.png
@@ -64,13 +64,43 @@ func ParseDaySchedule(schedule *types.Schedule, name string, daysAfterCurr int)
 
 	weekNum := getScheduleWeekNumDyDate(schedule, weekDate)
 
-	if IsWeekScheduleEmpty(schedule.Weeks[weekNum]) {
-		return nil, &types.UnavailableScheduleError{Name: name, WeekNum: weekNum, WeekDayNum: weekDayNum}
+	if weekNum >= len(schedule.Weeks) || IsWeekScheduleEmpty(schedule.Weeks[weekNum]) {
+		return nil, &types.UnavailableScheduleError{Name: name, Scope: types.DayScope, WeekNum: weekNum,
+			WeekDayNum: weekDayNum}
 	}
 
 	return &schedule.Weeks[weekNum].Days[weekDayNum], nil
 }
 
+// ParseWeekScheduleByDate returns *types.Week received from *types.Schedule based on the school week of the date.
+func ParseWeekScheduleByDate(schedule *types.Schedule, name string, date time.Time) (*types.Week, error) {
+	return parseWeekSchedule(schedule, name, date)
+}
+
+// ParseDayScheduleByDate returns *types.Day received from *types.Schedule based on the date.
+func ParseDayScheduleByDate(schedule *types.Schedule, name string, date time.Time) (*types.Day, error) {
+	week, err := parseWeekSchedule(schedule, name, date)
+	if err != nil {
+		return nil, err
+	}
+
+	_, weekDayNum := getWeekDateAndWeekDayByTime(date)
+	return &week.Days[weekDayNum], nil
+}
+
+// GetFullEntitySchedule returns the full schedule of the group or teacher from the UlSTU site. The room schedules
+// are not published on the site, so *types.NotFoundError is returned for them.
+func GetFullEntitySchedule(entity types.Entity) (*types.Schedule, error) {
+	switch entity.Type {
+	case types.Group:
+		return GetFullGroupSchedule(entity.Name)
+	case types.Teacher:
+		return GetFullTeacherSchedule(entity.Name)
+	default:
+		return nil, &types.NotFoundError{Name: entity.Name, Type: entity.Type}
+	}
+}
+
 // getDocFromURL returns goquery document representation of the page with the schedule.
 func getDocFromURL(URL string) (*goquery.Document, error) {
 	req, err := http.NewRequest(http.MethodGet, URL, nil)
@@ -80,7 +110,7 @@ func getDocFromURL(URL string) (*goquery.Document, error) {
 
 	response, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, &types.RequestError{URL: URL, Err: err}
 	}
 	defer func(Body io.ReadCloser) {
 		err = Body.Close()
@@ -91,6 +121,7 @@ func getDocFromURL(URL string) (*goquery.Document, error) {
 
 	if response.StatusCode >= 300 {
 		return nil, &types.StatusCodeError{
+			URL:        URL,
 			StatusCode: response.StatusCode,
 			StatusText: http.StatusText(response.StatusCode),
 		}
@@ -100,7 +131,11 @@ func getDocFromURL(URL string) (*goquery.Document, error) {
 	decoder := charmap.Windows1251.NewDecoder()
 	reader := decoder.Reader(response.Body)
 
-	return goquery.NewDocumentFromReader(reader)
+	doc, err := goquery.NewDocumentFromReader(reader)
+	if err != nil {
+		return nil, &types.RequestError{URL: URL, Err: err}
+	}
+	return doc, nil
 }
 
 // determineLessonType returns types.LessonType representation of a string.
@@ -187,9 +222,9 @@ func IsWeekScheduleEmpty(week types.Week) bool {
 // IsFullScheduleEmpty returns true if the full schedule is empty, otherwise - false.
 func IsFullScheduleEmpty(s *types.Schedule) bool {
 	wg := &sync.WaitGroup{}
-	inResultsEmptyCheck := make(chan bool, 2)
+	inResultsEmptyCheck := make(chan bool, len(s.Weeks))
 
-	for i := 0; i < 2; i++ {
+	for i := range s.Weeks {
 		wg.Add(1)
 		go func(weekNum int, wg *sync.WaitGroup, out chan bool) {
 			defer wg.Done()
@@ -198,8 +233,14 @@ func IsFullScheduleEmpty(s *types.Schedule) bool {
 	}
 
 	wg.Wait()
+	close(inResultsEmptyCheck)
 
-	return <-inResultsEmptyCheck && <-inResultsEmptyCheck
+	for isWeekEmpty := range inResultsEmptyCheck {
+		if !isWeekEmpty {
+			return false
+		}
+	}
+	return true
 }
 
 // getRandInt returns a non-negative pseudo-random int.
@@ -257,72 +298,94 @@ func setFont(fontSize float64, dc *gg.Context) {
 	dc.SetFontFace(face)
 }
 
-// getFullSchedule returns the full  schedule.
-func getFullSchedule(name string, url string, typeSchedule types.ScheduleType) (*types.Schedule, error) {
-	// this group is not on the website with a schedule (the name does not exist or the schedule has not been loaded yet)
-	if url == "" {
-		return nil, &types.UnavailableScheduleError{Name: name, WeekNum: -1, WeekDayNum: -1}
-	}
+// getFullSchedule returns the full  schedule. The problems found while parsing the table cells are added to diag.
+func getFullSchedule(name string, url string, typeSchedule types.ScheduleType, diag *diagnostics) (_ *types.Schedule, err error) {
+	defer recoverParseError(&err, &url, name, "schedule table")
 
 	doc, err := getDocFromURL(url)
 	if err != nil {
 		return nil, err
 	}
 
-	schedule := &types.Schedule{}
+	schedule, err := parseFullSchedule(doc, name, typeSchedule, diag)
+	if err != nil {
+		return nil, &types.ParseError{URL: url, Entity: name, Location: "schedule table", Cause: err}
+	}
+
+	if IsFullScheduleEmpty(schedule) {
+		return nil, &types.UnavailableScheduleError{Name: name, Scope: types.FullScope}
+	}
 
-	pSelection := doc.Find("p")
-	tablesSchedule := doc.Find("table")
+	return schedule, nil
+}
 
-	tablesSchedule.EachWithBreak(func(tableIdx int, tableS *goquery.Selection) bool {
-		if tableIdx == maxScheduleWeekCount {
-			return false
-		}
+// suggestNames returns up to maxSuggestionsNum group or teacher names that are nearest to the name.
+func suggestNames(name string, names []string, typeSchedule types.ScheduleType) []string {
+	candidates := NewResolver(typeSchedule, names).Resolve(name, maxSuggestionsNum)
+
+	suggestedNames := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		suggestedNames = append(suggestedNames, candidate.Name)
+	}
+	return suggestedNames
+}
+
+// getEditDistance returns the Levenshtein distance between two strings.
+func getEditDistance(a, b []rune) int {
+	prevRow := make([]int, len(b)+1)
+	currRow := make([]int, len(b)+1)
+	for j := range prevRow {
+		prevRow[j] = j
+	}
 
-		pTableSelection := tableS.Find("p")
-
-		weekNumStr := strings.Split(pSelection.Get(tableIdx*lengthScheduleTable).LastChild.LastChild.Data, ": ")[1]
-		weekNumDisplay, _ := strconv.Atoi(strings.Split(weekNumStr, "-")[0])
-		schedule.Weeks[tableIdx].Number = weekNumDisplay
-
-		pTableSelection.Each(func(pIdx int, pS *goquery.Selection) {
-			iMod10 := pIdx % 10
-			iDiv10 := pIdx / 10
-			// first week lessons. Условие для дней 21 <= i && i <= 79 && 1 <= iMod10 && iMod10 <= 9
-			if 20 <= pIdx && pIdx <= 79 && 0 <= iMod10 && iMod10 <= 8 {
-				dayIdx := iDiv10 - 2
-				lessonIdx := iMod10 - 1
-
-				if pIdx == 20 {
-					dateStartWeek, dateEndWeek := parseDateScheduleWeek(findStartDayWeek, pS)
-					schedule.Weeks[tableIdx].DateStart = dateStartWeek
-					schedule.Weeks[tableIdx].DateEnd = dateEndWeek
-					return
-				}
-
-				if pIdx != 20 && iMod10 == 0 {
-					return
-				}
-
-				schedule.Weeks[tableIdx].Days[dayIdx].WeekNumber = weekNumDisplay
-				if typeSchedule == types.Group {
-					schedule.Weeks[tableIdx].Days[dayIdx].Lessons[lessonIdx] = *parseGroupLesson(name, lessonIdx,
-						findTeacherAndRoom, findTeacher, findRoom, findSubGroup, pS)
-				}
-				if typeSchedule == types.Teacher {
-					schedule.Weeks[tableIdx].Days[dayIdx].Lessons[lessonIdx] = *parseTeacherLesson(name, lessonIdx, pS)
-				}
+	for i := 1; i <= len(a); i++ {
+		currRow[0] = i
+		for j := 1; j <= len(b); j++ {
+			substitutionCost := 1
+			if a[i-1] == b[j-1] {
+				substitutionCost = 0
 			}
-		})
+			currRow[j] = minInt(minInt(prevRow[j]+1, currRow[j-1]+1), prevRow[j-1]+substitutionCost)
+		}
+		prevRow, currRow = currRow, prevRow
+	}
+	return prevRow[len(b)]
+}
 
-		return true
-	})
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
 
-	if IsFullScheduleEmpty(schedule) {
-		return nil, &types.UnavailableScheduleError{Name: name, WeekNum: -1, WeekDayNum: -1}
+// checkScheduleTitle returns an error if the title of the schedule page does not contain the name of the group or
+// teacher. The title is the text of the last element of the first paragraph.
+func checkScheduleTitle(doc *goquery.Document, name string, url string) error {
+	titleS := doc.Find("p").First()
+	if titleS.Length() == 0 {
+		return &types.ParseError{URL: url, Entity: name, Location: "schedule title",
+			Cause: &types.LayoutError{Table: -1, Row: -1, Reason: "no title paragraph"}}
 	}
 
-	return schedule, nil
+	nameFromDoc := titleS.Text()
+	if lastChildS := titleS.Children().Last(); lastChildS.Length() > 0 {
+		nameFromDoc = lastChildS.Text()
+	}
+	nameFromDoc = strings.TrimSpace(nameFromDoc)
+
+	if !strings.Contains(nameFromDoc, name) {
+		return &types.IncorrectLinkError{Name: name, NameFromURL: nameFromDoc}
+	}
+	return nil
+}
+
+// recoverParseError converts the panic that occurred while parsing the page into *types.ParseError. It must be
+// deferred directly, url is a pointer because the parsed page may change during the parsing.
+func recoverParseError(err *error, url *string, name string, location string) {
+	if r := recover(); r != nil {
+		*err = &types.ParseError{URL: *url, Entity: name, Location: location, Cause: fmt.Errorf("panic: %v", r)}
+	}
 }
 
 // GetImgByWeekSchedule return path on img of schedule
@@ -332,6 +395,19 @@ func GetImgByWeekSchedule(
 	isCurrWeek bool,
 	headingFontSize float64,
 	drawLessonForWeekSchedule func(lesson *types.Lesson, x float64, y float64, dc *gg.Context)) (string, error) {
+	dc := drawWeekSchedule(schedule, name, isCurrWeek, headingFontSize, drawLessonForWeekSchedule)
+
+	weekSchedulePath := fmt.Sprintf("week_schedule%d.png", getRandInt())
+	return weekSchedulePath, dc.SavePNG(weekSchedulePath)
+}
+
+// drawWeekSchedule returns the context with the template of the table filled with the lessons of the week schedule.
+func drawWeekSchedule(
+	schedule *types.Week,
+	name string,
+	isCurrWeek bool,
+	headingFontSize float64,
+	drawLessonForWeekSchedule func(lesson *types.Lesson, x float64, y float64, dc *gg.Context)) *gg.Context {
 	// loads an template of an empty table that will be filled in pairs
 	tableImg := getWeekScheduleTmplImg(weekScheduleTemp)
 	dc := gg.NewContextForImage(tableImg)
@@ -355,7 +431,11 @@ func GetImgByWeekSchedule(
 		}
 		scheduleDay := schedule.Days[rowNum]
 
-		for _, lesson := range scheduleDay.Lessons {
+		for lessonIdx, lesson := range scheduleDay.Lessons {
+			// the template table has columns only for the lessons of the default time table
+			if lessonIdx == len(types.DefaultTimeTable.Slots) {
+				break
+			}
 			if len(lesson.SubLessons) > 0 {
 				drawLessonForWeekSchedule(&lesson, x, y, dc)
 			}
@@ -364,42 +444,112 @@ func GetImgByWeekSchedule(
 		}
 	}
 
-	weekSchedulePath := fmt.Sprintf("week_schedule%d.png", getRandInt())
-	return weekSchedulePath, dc.SavePNG(weekSchedulePath)
+	return dc
+}
+
+// ParseWeekScheduleImg returns the path to the image with the week schedule of the group, teacher or room.
+func ParseWeekScheduleImg(schedule *types.Week, name string, typeSchedule types.ScheduleType,
+	isCurrWeek bool) (string, error) {
+	headingFontSize, drawLessonForWeekSchedule := getWeekScheduleImgStyle(typeSchedule)
+	return GetImgByWeekSchedule(schedule, name, isCurrWeek, headingFontSize, drawLessonForWeekSchedule)
+}
+
+// ConvertDayScheduleToText converts the day schedule into text as it is displayed for the type of the schedule.
+func ConvertDayScheduleToText(day *types.Day, name string, typeSchedule types.ScheduleType, daysAfterCurr int) string {
+	switch typeSchedule {
+	case types.Teacher:
+		return ConvertDayTeacherScheduleToText(name, *day, daysAfterCurr)
+	case types.Room:
+		return ConvertDayRoomScheduleToText(name, *day, daysAfterCurr)
+	default:
+		return ConvertDayGroupScheduleToText(day, name, daysAfterCurr)
+	}
+}
+
+// RenderWeekScheduleImg returns the PNG image with the week schedule of the group, teacher or room. Unlike
+// ParseWeekScheduleImg, the image is encoded in memory and is not saved to the working directory.
+func RenderWeekScheduleImg(schedule *types.Week, name string, typeSchedule types.ScheduleType,
+	isCurrWeek bool) ([]byte, error) {
+	headingFontSize, drawLessonForWeekSchedule := getWeekScheduleImgStyle(typeSchedule)
+	dc := drawWeekSchedule(schedule, name, isCurrWeek, headingFontSize, drawLessonForWeekSchedule)
+
+	img := &bytes.Buffer{}
+	if err := dc.EncodePNG(img); err != nil {
+		return nil, err
+	}
+	return img.Bytes(), nil
+}
+
+// getWeekScheduleImgStyle returns the font size of the heading and the function that draws the lessons in the image
+// with the week schedule of the type.
+func getWeekScheduleImgStyle(typeSchedule types.ScheduleType) (float64,
+	func(lesson *types.Lesson, x float64, y float64, dc *gg.Context)) {
+	switch typeSchedule {
+	case types.Teacher:
+		return headingTableTeacherFontSize, drawTeacherLessonForWeekSchedule
+	case types.Room:
+		// the room lessons are drawn as the teacher ones: with the groups and the room
+		return headingTableRoomFontSize, drawTeacherLessonForWeekSchedule
+	default:
+		return headingTableGroupFontSize, drawGroupLessonForWeekSchedule
+	}
 }
 
 // ParseWeekSchedule returns *types.Week received from *types.Schedule based on the selected school week.
 func parseWeekSchedule(schedule *types.Schedule, name string, weekDate time.Time) (*types.Week, error) {
-	weekNum := getScheduleWeekNumDyDate(schedule, weekDate)
-
-	if IsWeekScheduleEmpty(schedule.Weeks[weekNum]) {
-		return nil, &types.UnavailableScheduleError{Name: name, WeekNum: weekNum, WeekDayNum: -1}
+	if len(schedule.Weeks) == 0 {
+		return nil, &types.UnavailableScheduleError{Name: name, Scope: types.FullScope}
 	}
 
-	if schedule.Weeks[0].Number < 3 && schedule.Weeks[1].Number < 3 {
-		if weekNum < 0 || weekNum > 1 {
-			return nil, &types.IncorrectWeekNumberError{WeekNum: weekNum}
-		}
-		return &schedule.Weeks[weekNum], nil
+	weekNum := getScheduleWeekNumDyDate(schedule, weekDate)
+
+	if weekNum < 0 || weekNum >= len(schedule.Weeks) {
+		return nil, &types.IncorrectWeekNumberError{WeekNum: weekNum}
 	}
 
-	if weekNum > 1 {
-		return nil, &types.UnavailableScheduleError{Name: name, WeekNum: weekNum, WeekDayNum: -1}
+	if IsWeekScheduleEmpty(schedule.Weeks[weekNum]) {
+		return nil, &types.UnavailableScheduleError{Name: name, Scope: types.WeekScope, WeekNum: weekNum}
 	}
 
 	return &schedule.Weeks[weekNum], nil
 }
 
+// getScheduleWeekNumDyDate returns the index of the schedule week that contains weekDate. If none of the published
+// weeks contains the date, the week is chosen by the rotation of the school weeks relative to the first one, both
+// after and before it.
 func getScheduleWeekNumDyDate(schedule *types.Schedule, weekDate time.Time) int {
-	var weekNumSchedule int
 	for weekNum, week := range schedule.Weeks {
 		isInRange := isInTimeRange(weekDate, week.DateStart, week.DateEnd)
 		if isInRange {
-			weekNumSchedule = weekNum
-			break
+			return weekNum
 		}
 	}
-	return weekNumSchedule
+
+	if len(schedule.Weeks) < 2 {
+		return 0
+	}
+
+	// the weeks before the first one are counted back from it, so the floor is used instead of the truncation
+	weeksAfterFirst := int(math.Floor(weekDate.Sub(schedule.Weeks[0].DateStart).Hours() / 24 / 7))
+	weekNum, _ := schedule.WeekIdxByNumber(schedule.Weeks[0].Number + weeksAfterFirst)
+	return weekNum
+}
+
+// getScheduleDayByDate returns the day of the schedule week chosen for the date by getScheduleWeekNumDyDate. Unlike
+// ParseDayScheduleByDate, the day is returned even if the week has no lessons. Returns false if the schedule has no
+// weeks.
+func getScheduleDayByDate(schedule *types.Schedule, date time.Time) (*types.Day, bool) {
+	if len(schedule.Weeks) == 0 {
+		return nil, false
+	}
+
+	weekNum := getScheduleWeekNumDyDate(schedule, date)
+	if weekNum < 0 || weekNum >= len(schedule.Weeks) {
+		return nil, false
+	}
+
+	_, weekDayNum := getWeekDateAndWeekDayByTime(date)
+	return &schedule.Weeks[weekNum].Days[weekDayNum], true
 }
 
 func isInTimeRange(weekDate time.Time, start time.Time, end time.Time) bool {
@@ -1,10 +1,19 @@
 package schedule
 
 import (
-	"github.com/stretchr/testify/assert"
-	"github.com/ulstu-schedule/parser/mock"
+	"bytes"
+	"errors"
+	"image"
+	_ "image/png"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/mock"
+	"github.com/ulstu-schedule/parser/types"
 )
 
 func TestIsInTimeRange(t *testing.T) {
@@ -54,12 +63,159 @@ func TestGetScheduleWeekNumDyDate(t *testing.T) {
 
 		assert.EqualValues(t, 0, weekNum)
 	})
-	t.Run("invalid date", func(t *testing.T) {
+	t.Run("correct. Rotation before the published weeks", func(t *testing.T) {
+		groupSchedule := mock.TestGroupSchedule(t)
+
+		// the 10th week takes the place of the 12th one
 		weekDate, _ := time.Parse("2006-01-02", "2024-04-10")
+		assert.EqualValues(t, 1, getScheduleWeekNumDyDate(groupSchedule, weekDate))
 
+		weekDate, _ = time.Parse("2006-01-02", "2024-04-03")
+		assert.EqualValues(t, 0, getScheduleWeekNumDyDate(groupSchedule, weekDate))
+	})
+	t.Run("correct. Rotation after the published weeks", func(t *testing.T) {
 		groupSchedule := mock.TestGroupSchedule(t)
-		weekNum := getScheduleWeekNumDyDate(groupSchedule, weekDate)
 
-		assert.EqualValues(t, 0, weekNum)
+		weekDate, _ := time.Parse("2006-01-02", "2024-05-01")
+		assert.EqualValues(t, 0, getScheduleWeekNumDyDate(groupSchedule, weekDate))
+
+		weekDate, _ = time.Parse("2006-01-02", "2024-05-08")
+		assert.EqualValues(t, 1, getScheduleWeekNumDyDate(groupSchedule, weekDate))
+	})
+}
+
+func TestParseWeekSchedule(t *testing.T) {
+	t.Run("one week schedule", func(t *testing.T) {
+		groupSchedule := mock.TestGroupSchedule(t)
+		groupSchedule.Weeks = groupSchedule.Weeks[1:]
+
+		weekDate, _ := time.Parse("2006-01-02", "2024-05-01")
+		week, err := parseWeekSchedule(groupSchedule, "АТсд-21", weekDate)
+
+		assert.NoError(t, err)
+		assert.EqualValues(t, 12, week.Number)
+	})
+	t.Run("empty schedule", func(t *testing.T) {
+		weekDate, _ := time.Parse("2006-01-02", "2024-05-01")
+		_, err := parseWeekSchedule(&types.Schedule{}, "АТсд-21", weekDate)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestParseDayScheduleByDate(t *testing.T) {
+	groupSchedule := mock.TestGroupSchedule(t)
+
+	date := time.Date(2024, 4, 25, 12, 0, 0, 0, time.UTC)
+	day, err := ParseDayScheduleByDate(groupSchedule, "АТсд-21", date)
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, 12, day.WeekNumber)
+	assert.EqualValues(t, "Спецглавы математики", day.Lessons[3].SubLessons[0].Name)
+}
+
+func TestRenderWeekScheduleImg(t *testing.T) {
+	groupSchedule := mock.TestGroupSchedule(t)
+
+	img, err := RenderWeekScheduleImg(&groupSchedule.Weeks[0], "АТсд-21", types.Group, true)
+
+	assert.NoError(t, err)
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(img))
+	assert.NoError(t, err)
+	assert.EqualValues(t, "png", format)
+	assert.EqualValues(t, imgWidth, cfg.Width)
+}
+
+func TestCheckScheduleTitle(t *testing.T) {
+	t.Run("correct", func(t *testing.T) {
+		doc := testScheduleDoc(t, "<p><font>Расписание занятий группы: </font><b>АТсд-21</b></p>")
+
+		assert.NoError(t, checkScheduleTitle(doc, "АТсд-21", "raspisan.html"))
+	})
+	t.Run("another group", func(t *testing.T) {
+		doc := testScheduleDoc(t, "<p><font>Расписание занятий группы: </font><b>АТсд-11</b></p>")
+
+		err := checkScheduleTitle(doc, "АТсд-21", "raspisan.html")
+
+		var linkErr *types.IncorrectLinkError
+		assert.True(t, errors.As(err, &linkErr))
+		assert.EqualValues(t, "АТсд-11", linkErr.NameFromURL)
+	})
+	t.Run("no title", func(t *testing.T) {
+		doc := testScheduleDoc(t, "<div>АТсд-21</div>")
+
+		err := checkScheduleTitle(doc, "АТсд-21", "raspisan.html")
+
+		var parseErr *types.ParseError
+		assert.True(t, errors.As(err, &parseErr))
+	})
+}
+
+func TestRecoverParseError(t *testing.T) {
+	parse := func() (err error) {
+		url := "raspisan.html"
+		defer recoverParseError(&err, &url, "АТсд-21", "schedule table")
+
+		var cells []string
+		_ = cells[1]
+		return nil
+	}
+
+	err := parse()
+
+	var parseErr *types.ParseError
+	assert.True(t, errors.As(err, &parseErr))
+	assert.EqualValues(t, "raspisan.html", parseErr.URL)
+	assert.EqualValues(t, "АТсд-21", parseErr.Entity)
+}
+
+func TestGetDocFromURL(t *testing.T) {
+	t.Run("status code error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		_, err := getDocFromURL(server.URL)
+
+		var statusCodeErr *types.StatusCodeError
+		assert.True(t, errors.As(err, &statusCodeErr))
+		assert.EqualValues(t, http.StatusBadGateway, statusCodeErr.StatusCode)
+		assert.True(t, errors.Is(err, types.ErrSiteUnavailable))
+	})
+	t.Run("network error", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		_, err := getDocFromURL(server.URL)
+
+		var urlErr *url.Error
+		assert.True(t, errors.As(err, &urlErr))
+		assert.True(t, errors.Is(err, types.ErrSiteUnavailable))
 	})
 }
+
+func TestScheduleErrors(t *testing.T) {
+	t.Run("not published", func(t *testing.T) {
+		weekDate, _ := time.Parse("2006-01-02", "2024-05-01")
+		_, err := parseWeekSchedule(&types.Schedule{}, "АТсд-21", weekDate)
+
+		assert.True(t, errors.Is(err, types.ErrNotPublished))
+		assert.False(t, errors.Is(err, types.ErrNotFound))
+	})
+	t.Run("layout changed", func(t *testing.T) {
+		doc := testScheduleDoc(t, "<p>Расписание не опубликовано</p>")
+		_, err := parseFullSchedule(doc, "АТсд-21", types.Group, nil)
+
+		assert.True(t, errors.Is(err, types.ErrLayoutChanged))
+	})
+}
+
+func TestSuggestNames(t *testing.T) {
+	groups := []string{"АТсд-21", "АТсд-11", "ИДбв-11", "ПГСбв-21"}
+	teachers := []string{"Зенкина С М", "Рандин А В"}
+
+	assert.EqualValues(t, []string{"АТсд-21", "АТсд-11"}, suggestNames("атсд-21", groups, types.Group))
+	assert.EqualValues(t, []string{"Зенкина С М"}, suggestNames("Зенкина С", teachers, types.Teacher))
+	assert.Empty(t, suggestNames("Иванов И И", teachers, types.Teacher))
+}
@@ -0,0 +1,231 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ulstu-schedule/parser/types"
+)
+
+const (
+	defaultWatcherInterval         = 15 * time.Minute
+	defaultWatcherMaxBackoffFactor = 8
+	// watcherIdleDelay is the delay between the checks of the subscriptions when there are none
+	watcherIdleDelay = time.Minute
+)
+
+// WatcherStore keeps the last seen versions of the watched schedules. It is implemented by the backends of the
+// storage package.
+type WatcherStore interface {
+	Save(entity types.Entity, schedule *types.Schedule, fetchedAt time.Time) error
+	Load(entity types.Entity, at time.Time) (*types.Schedule, time.Time, error)
+}
+
+// ChangeEvent represents the changes of the watched schedule found after it was re-fetched.
+type ChangeEvent struct {
+	Entity    types.Entity
+	Changes   []types.Change
+	Schedule  *types.Schedule // new version of the schedule
+	FetchedAt time.Time
+}
+
+// Watcher periodically re-fetches the schedules of the subscribed groups and teachers, compares them with the last
+// seen versions from the store and reports the changes. The first fetched version of the schedule is saved to the
+// store without reporting.
+type Watcher struct {
+	// Interval is the delay between the checks of the schedule, 15 minutes if not positive
+	Interval time.Duration
+	// Jitter is the maximum random delay added to Interval, so the checks are not made at the same time
+	Jitter time.Duration
+	// MaxBackoff is the maximum delay between the checks of the schedule that cannot be fetched, the delay is
+	// doubled after each failed check. Equals 8 intervals if not positive
+	MaxBackoff time.Duration
+	// OnChange is called when the schedule has changed
+	OnChange func(event ChangeEvent)
+	// OnError is called when the schedule cannot be fetched or stored
+	OnError func(entity types.Entity, err error)
+
+	store WatcherStore
+	fetch func(entity types.Entity) (*types.Schedule, error)
+
+	mu            sync.Mutex
+	subscriptions map[types.Entity]*watchState
+	wake          chan struct{}
+}
+
+// watchState is the state of the checks of the subscribed schedule.
+type watchState struct {
+	nextCheck time.Time
+	failures  int
+}
+
+// NewWatcher returns *Watcher that checks the schedules every interval and keeps the last seen versions in store.
+func NewWatcher(store WatcherStore, interval time.Duration) *Watcher {
+	return &Watcher{
+		Interval:      interval,
+		store:         store,
+		fetch:         GetFullEntitySchedule,
+		subscriptions: map[types.Entity]*watchState{},
+		wake:          make(chan struct{}, 1),
+	}
+}
+
+// Subscribe adds the schedule of the group or teacher to the watched ones. The schedule is checked as soon as
+// possible.
+func (w *Watcher) Subscribe(entity types.Entity) {
+	w.mu.Lock()
+	if _, ok := w.subscriptions[entity]; !ok {
+		w.subscriptions[entity] = &watchState{}
+	}
+	w.mu.Unlock()
+
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Unsubscribe removes the schedule from the watched ones.
+func (w *Watcher) Unsubscribe(entity types.Entity) {
+	w.mu.Lock()
+	delete(w.subscriptions, entity)
+	w.mu.Unlock()
+}
+
+// Run checks the subscribed schedules until ctx is done. Returns ctx.Err().
+func (w *Watcher) Run(ctx context.Context) error {
+	for {
+		for _, entity := range w.getDueEntities(time.Now()) {
+			event, err := w.Check(entity)
+			w.scheduleNextCheck(entity, err)
+
+			if err != nil {
+				if w.OnError != nil {
+					w.OnError(entity, err)
+				}
+				continue
+			}
+			if event != nil && w.OnChange != nil {
+				w.OnChange(*event)
+			}
+		}
+
+		timer := time.NewTimer(w.getNextCheckDelay(time.Now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-w.wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// Check fetches the schedule, compares it with the last seen version and saves the new version to the store if it
+// has changed. Returns nil *ChangeEvent if there are no changes or the schedule is fetched for the first time.
+func (w *Watcher) Check(entity types.Entity) (*ChangeEvent, error) {
+	schedule, err := w.fetch(entity)
+	if err != nil {
+		return nil, err
+	}
+	fetchedAt := time.Now()
+
+	lastSchedule, _, err := w.store.Load(entity, time.Time{})
+	if errors.Is(err, types.ErrNotStored) {
+		return nil, w.store.Save(entity, schedule, fetchedAt)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	changes := Diff(lastSchedule, schedule)
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	if err = w.store.Save(entity, schedule, fetchedAt); err != nil {
+		return nil, err
+	}
+	return &ChangeEvent{Entity: entity, Changes: changes, Schedule: schedule, FetchedAt: fetchedAt}, nil
+}
+
+// getDueEntities returns the subscribed entities which schedules must be checked at now.
+func (w *Watcher) getDueEntities(now time.Time) []types.Entity {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	entities := make([]types.Entity, 0)
+	for entity, state := range w.subscriptions {
+		if !state.nextCheck.After(now) {
+			entities = append(entities, entity)
+		}
+	}
+	return entities
+}
+
+// getNextCheckDelay returns the delay until the next check of the subscribed schedules.
+func (w *Watcher) getNextCheckDelay(now time.Time) time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delay := watcherIdleDelay
+	for _, state := range w.subscriptions {
+		if stateDelay := state.nextCheck.Sub(now); stateDelay < delay {
+			delay = stateDelay
+		}
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	return delay
+}
+
+// scheduleNextCheck sets the time of the next check of the schedule based on the result of the last check.
+func (w *Watcher) scheduleNextCheck(entity types.Entity, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	state, ok := w.subscriptions[entity]
+	if !ok {
+		return
+	}
+
+	if err != nil {
+		state.failures++
+	} else {
+		state.failures = 0
+	}
+	state.nextCheck = time.Now().Add(w.getCheckDelay(state.failures))
+}
+
+// getCheckDelay returns the delay until the next check of the schedule after the number of failed checks in a row.
+func (w *Watcher) getCheckDelay(failures int) time.Duration {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = defaultWatcherInterval
+	}
+
+	delay := interval
+	if failures > 0 {
+		maxBackoff := w.MaxBackoff
+		if maxBackoff <= 0 {
+			maxBackoff = interval * defaultWatcherMaxBackoffFactor
+		}
+
+		for i := 0; i < failures && delay < maxBackoff; i++ {
+			delay *= 2
+		}
+		if delay > maxBackoff {
+			delay = maxBackoff
+		}
+	}
+
+	if w.Jitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(w.Jitter)))
+	}
+	return delay
+}
@@ -0,0 +1,116 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/mock"
+	"github.com/ulstu-schedule/parser/storage"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+var testWatchedGroup = types.Entity{Type: types.Group, Name: "АТсд-21"}
+
+func TestWatcherCheck(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	w := NewWatcher(store, time.Hour)
+
+	schedule := mock.TestGroupSchedule(t)
+	w.fetch = func(types.Entity) (*types.Schedule, error) {
+		return schedule, nil
+	}
+
+	t.Run("first fetch", func(t *testing.T) {
+		event, err := w.Check(testWatchedGroup)
+		assert.NoError(t, err)
+		assert.Nil(t, event)
+
+		_, _, err = store.Load(testWatchedGroup, time.Time{})
+		assert.NoError(t, err)
+	})
+	t.Run("no changes", func(t *testing.T) {
+		event, err := w.Check(testWatchedGroup)
+		assert.NoError(t, err)
+		assert.Nil(t, event)
+	})
+	t.Run("changed", func(t *testing.T) {
+		schedule = mock.TestGroupSchedule(t)
+		schedule.Weeks[0].Days[1].Lessons[1].SubLessons[0].Room = "6-402"
+
+		event, err := w.Check(testWatchedGroup)
+		assert.NoError(t, err)
+		assert.EqualValues(t, testWatchedGroup, event.Entity)
+		assert.Len(t, event.Changes, 1)
+		assert.EqualValues(t, types.RoomChanged, event.Changes[0].Kind)
+
+		lastSchedule, _, err := store.Load(testWatchedGroup, time.Time{})
+		assert.NoError(t, err)
+		assert.Equal(t, schedule, lastSchedule)
+	})
+	t.Run("fetch error", func(t *testing.T) {
+		fetchErr := &types.StatusCodeError{StatusCode: 503}
+		w.fetch = func(types.Entity) (*types.Schedule, error) {
+			return nil, fetchErr
+		}
+
+		_, err := w.Check(testWatchedGroup)
+		assert.True(t, errors.Is(err, types.ErrSiteUnavailable))
+	})
+}
+
+func TestWatcherRun(t *testing.T) {
+	w := NewWatcher(storage.NewMemoryStorage(), time.Millisecond)
+
+	fetchesNum := 0
+	w.fetch = func(types.Entity) (*types.Schedule, error) {
+		fetchesNum++
+		schedule := mock.TestGroupSchedule(t)
+		if fetchesNum > 1 {
+			schedule.Weeks[0].Days[1].Lessons[1].SubLessons[0].Room = "6-402"
+		}
+		return schedule, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events := make([]ChangeEvent, 0)
+	w.OnChange = func(event ChangeEvent) {
+		events = append(events, event)
+		cancel()
+	}
+	w.Subscribe(testWatchedGroup)
+
+	err := w.Run(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, events, 1)
+	assert.EqualValues(t, types.RoomChanged, events[0].Changes[0].Kind)
+}
+
+func TestWatcherGetCheckDelay(t *testing.T) {
+	w := NewWatcher(storage.NewMemoryStorage(), time.Minute)
+
+	assert.EqualValues(t, time.Minute, w.getCheckDelay(0))
+	assert.EqualValues(t, 2*time.Minute, w.getCheckDelay(1))
+	assert.EqualValues(t, 4*time.Minute, w.getCheckDelay(2))
+	assert.EqualValues(t, 8*time.Minute, w.getCheckDelay(10))
+
+	w.MaxBackoff = 3 * time.Minute
+	assert.EqualValues(t, 3*time.Minute, w.getCheckDelay(2))
+
+	w.Jitter = time.Second
+	delay := w.getCheckDelay(0)
+	assert.True(t, delay >= time.Minute && delay < time.Minute+time.Second)
+
+	// the site is not polled in a loop if the interval is not positive
+	w = NewWatcher(storage.NewMemoryStorage(), 0)
+	assert.EqualValues(t, 15*time.Minute, w.getCheckDelay(0))
+	assert.EqualValues(t, 30*time.Minute, w.getCheckDelay(1))
+	assert.EqualValues(t, 2*time.Hour, w.getCheckDelay(10))
+
+	w.Interval = -time.Minute
+	assert.EqualValues(t, 15*time.Minute, w.getCheckDelay(0))
+}
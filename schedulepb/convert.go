@@ -0,0 +1,254 @@
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative schedule.proto
+
+// Package schedulepb contains the protobuf messages and the gRPC service of the schedules, and the conversions
+// between the messages and the types of the types package.
+package schedulepb
+
+import (
+	"time"
+
+	"github.com/ulstu-schedule/parser/types"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// FromEntity converts types.Entity to *Entity.
+func FromEntity(entity types.Entity) *Entity {
+	return &Entity{Type: ScheduleType(entity.Type), Name: entity.Name}
+}
+
+// ToEntity converts *Entity to types.Entity.
+func ToEntity(entity *Entity) types.Entity {
+	return types.Entity{Type: types.ScheduleType(entity.GetType()), Name: entity.GetName()}
+}
+
+// FromSchedule converts *types.Schedule to *Schedule.
+func FromSchedule(schedule *types.Schedule) *Schedule {
+	if schedule == nil {
+		return nil
+	}
+
+	pbSchedule := &Schedule{Weeks: make([]*Week, 0, len(schedule.Weeks))}
+	for _, week := range schedule.Weeks {
+		pbSchedule.Weeks = append(pbSchedule.Weeks, FromWeek(week))
+	}
+	return pbSchedule
+}
+
+// ToSchedule converts *Schedule to *types.Schedule.
+func ToSchedule(schedule *Schedule) *types.Schedule {
+	if schedule == nil {
+		return nil
+	}
+
+	typesSchedule := &types.Schedule{}
+	for _, week := range schedule.Weeks {
+		typesSchedule.Weeks = append(typesSchedule.Weeks, ToWeek(week))
+	}
+	return typesSchedule
+}
+
+// FromWeek converts types.Week to *Week.
+func FromWeek(week types.Week) *Week {
+	pbWeek := &Week{
+		Number:    int32(week.Number),
+		DateStart: fromTime(week.DateStart),
+		DateEnd:   fromTime(week.DateEnd),
+		Days:      make([]*Day, 0, len(week.Days)),
+	}
+	for _, day := range week.Days {
+		pbWeek.Days = append(pbWeek.Days, FromDay(day))
+	}
+	return pbWeek
+}
+
+// ToWeek converts *Week to types.Week. The days after the seventh one are ignored.
+func ToWeek(week *Week) types.Week {
+	typesWeek := types.Week{
+		Number:    int(week.GetNumber()),
+		DateStart: toTime(week.GetDateStart()),
+		DateEnd:   toTime(week.GetDateEnd()),
+	}
+	for dayIdx, day := range week.GetDays() {
+		if dayIdx >= len(typesWeek.Days) {
+			break
+		}
+		typesWeek.Days[dayIdx] = ToDay(day)
+	}
+	return typesWeek
+}
+
+// FromDay converts types.Day to *Day.
+func FromDay(day types.Day) *Day {
+	pbDay := &Day{WeekNumber: int32(day.WeekNumber), Lessons: make([]*Lesson, 0, len(day.Lessons))}
+	for _, lesson := range day.Lessons {
+		pbDay.Lessons = append(pbDay.Lessons, FromLesson(lesson))
+	}
+	return pbDay
+}
+
+// ToDay converts *Day to types.Day.
+func ToDay(day *Day) types.Day {
+	typesDay := types.Day{WeekNumber: int(day.GetWeekNumber())}
+	for _, lesson := range day.GetLessons() {
+		typesDay.Lessons = append(typesDay.Lessons, ToLesson(lesson))
+	}
+	return typesDay
+}
+
+// FromLesson converts types.Lesson to *Lesson.
+func FromLesson(lesson types.Lesson) *Lesson {
+	pbLesson := &Lesson{SubLessons: make([]*SubLesson, 0, len(lesson.SubLessons))}
+	for _, subLesson := range lesson.SubLessons {
+		pbLesson.SubLessons = append(pbLesson.SubLessons, FromSubLesson(subLesson))
+	}
+	return pbLesson
+}
+
+// ToLesson converts *Lesson to types.Lesson. The lesson without sub lessons has nil SubLessons, as the parsed ones.
+func ToLesson(lesson *Lesson) types.Lesson {
+	typesLesson := types.Lesson{}
+	for _, subLesson := range lesson.GetSubLessons() {
+		typesLesson.SubLessons = append(typesLesson.SubLessons, ToSubLesson(subLesson))
+	}
+	return typesLesson
+}
+
+// FromSubLesson converts types.SubLesson to *SubLesson.
+func FromSubLesson(subLesson types.SubLesson) *SubLesson {
+	return &SubLesson{
+		Duration:    int32(subLesson.Duration),
+		Type:        LessonType(subLesson.Type),
+		Group:       subLesson.Group,
+		Name:        subLesson.Name,
+		Teacher:     subLesson.Teacher,
+		Room:        subLesson.Room,
+		Practice:    subLesson.Practice,
+		SubGroup:    subLesson.SubGroup,
+		SubGroupNum: int32(subLesson.SubGroupNum),
+	}
+}
+
+// ToSubLesson converts *SubLesson to types.SubLesson.
+func ToSubLesson(subLesson *SubLesson) types.SubLesson {
+	return types.SubLesson{
+		Duration:    types.Duration(subLesson.GetDuration()),
+		Type:        types.LessonType(subLesson.GetType()),
+		Group:       subLesson.GetGroup(),
+		Name:        subLesson.GetName(),
+		Teacher:     subLesson.GetTeacher(),
+		Room:        subLesson.GetRoom(),
+		Practice:    subLesson.GetPractice(),
+		SubGroup:    subLesson.GetSubGroup(),
+		SubGroupNum: int(subLesson.GetSubGroupNum()),
+	}
+}
+
+// FromGroupInfo converts types.GroupInfo to *GroupInfo.
+func FromGroupInfo(group types.GroupInfo) *GroupInfo {
+	return &GroupInfo{
+		Name:      group.Name,
+		Part:      int32(group.Part),
+		Faculties: group.Faculties,
+		Course:    int32(group.Course),
+		StudyForm: StudyForm(group.StudyForm),
+		Degree:    Degree(group.Degree),
+		Url:       group.URL,
+	}
+}
+
+// ToGroupInfo converts *GroupInfo to types.GroupInfo.
+func ToGroupInfo(group *GroupInfo) types.GroupInfo {
+	return types.GroupInfo{
+		Name:      group.GetName(),
+		Part:      int(group.GetPart()),
+		Faculties: group.GetFaculties(),
+		Course:    int(group.GetCourse()),
+		StudyForm: types.StudyForm(group.GetStudyForm()),
+		Degree:    types.Degree(group.GetDegree()),
+		URL:       group.GetUrl(),
+	}
+}
+
+// FromTeacherInfo converts types.TeacherInfo to *TeacherInfo.
+func FromTeacherInfo(teacher types.TeacherInfo) *TeacherInfo {
+	return &TeacherInfo{
+		Name:       teacher.Name,
+		Department: teacher.Department,
+		Position:   teacher.Position,
+		Details:    teacher.Details,
+		IsPseudo:   teacher.IsPseudo,
+		Url:        teacher.URL,
+	}
+}
+
+// ToTeacherInfo converts *TeacherInfo to types.TeacherInfo.
+func ToTeacherInfo(teacher *TeacherInfo) types.TeacherInfo {
+	return types.TeacherInfo{
+		Name:       teacher.GetName(),
+		Department: teacher.GetDepartment(),
+		Position:   teacher.GetPosition(),
+		Details:    teacher.GetDetails(),
+		IsPseudo:   teacher.GetIsPseudo(),
+		URL:        teacher.GetUrl(),
+	}
+}
+
+// FromChange converts types.Change to *Change.
+func FromChange(change types.Change) *Change {
+	pbChange := &Change{
+		Kind:           ChangeKind(change.Kind),
+		WeekNumber:     int32(change.WeekNumber),
+		WeekDayNum:     int32(change.WeekDayNum),
+		LessonNum:      int32(change.LessonNum),
+		Date:           fromTime(change.Date),
+		PrevWeekDayNum: int32(change.PrevWeekDayNum),
+		PrevLessonNum:  int32(change.PrevLessonNum),
+		PrevDate:       fromTime(change.PrevDate),
+	}
+	if change.Before != nil {
+		pbChange.Before = FromSubLesson(*change.Before)
+	}
+	if change.After != nil {
+		pbChange.After = FromSubLesson(*change.After)
+	}
+	return pbChange
+}
+
+// ToChange converts *Change to types.Change.
+func ToChange(change *Change) types.Change {
+	typesChange := types.Change{
+		Kind:           types.ChangeKind(change.GetKind()),
+		WeekNumber:     int(change.GetWeekNumber()),
+		WeekDayNum:     int(change.GetWeekDayNum()),
+		LessonNum:      int(change.GetLessonNum()),
+		Date:           toTime(change.GetDate()),
+		PrevWeekDayNum: int(change.GetPrevWeekDayNum()),
+		PrevLessonNum:  int(change.GetPrevLessonNum()),
+		PrevDate:       toTime(change.GetPrevDate()),
+	}
+	if change.GetBefore() != nil {
+		before := ToSubLesson(change.GetBefore())
+		typesChange.Before = &before
+	}
+	if change.GetAfter() != nil {
+		after := ToSubLesson(change.GetAfter())
+		typesChange.After = &after
+	}
+	return typesChange
+}
+
+// fromTime converts time.Time to *timestamppb.Timestamp. Returns nil for the zero time.
+func fromTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// toTime converts *timestamppb.Timestamp to time.Time in UTC. Returns the zero time for nil.
+func toTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
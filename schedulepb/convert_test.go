@@ -0,0 +1,70 @@
+package schedulepb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/mock"
+	"github.com/ulstu-schedule/parser/types"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestConvertSchedule(t *testing.T) {
+	groupSchedule := mock.TestGroupSchedule(t)
+
+	data, err := proto.Marshal(FromSchedule(groupSchedule))
+	assert.NoError(t, err)
+	pbSchedule := &Schedule{}
+	assert.NoError(t, proto.Unmarshal(data, pbSchedule))
+
+	converted := ToSchedule(pbSchedule)
+	assert.Len(t, converted.Weeks, len(groupSchedule.Weeks))
+	for weekIdx, week := range groupSchedule.Weeks {
+		assert.True(t, week.DateStart.Equal(converted.Weeks[weekIdx].DateStart))
+		assert.True(t, week.DateEnd.Equal(converted.Weeks[weekIdx].DateEnd))
+
+		// the timestamps are converted to UTC
+		converted.Weeks[weekIdx].DateStart, converted.Weeks[weekIdx].DateEnd = week.DateStart, week.DateEnd
+
+		// the lessons without sub lessons are converted as the parsed ones
+		for dayIdx := range week.Days {
+			for lessonIdx, lesson := range week.Days[dayIdx].Lessons {
+				if len(lesson.SubLessons) == 0 {
+					groupSchedule.Weeks[weekIdx].Days[dayIdx].Lessons[lessonIdx].SubLessons = nil
+				}
+			}
+		}
+	}
+	assert.EqualValues(t, groupSchedule, converted)
+
+	assert.Nil(t, FromSchedule(nil))
+	assert.Nil(t, ToSchedule(nil))
+}
+
+func TestConvertLists(t *testing.T) {
+	group := types.GroupInfo{Name: "АТсд-21", Part: 2, Faculties: "ФИСТ", Course: 2, StudyForm: types.PartTime,
+		Degree: types.Master, URL: "https://lk.ulstu.ru/timetable/shared/schedule/Часть%202/raspisan.html"}
+	assert.EqualValues(t, group, ToGroupInfo(FromGroupInfo(group)))
+
+	teacher := types.TeacherInfo{Name: "Зенкина С М", Department: "Дизайн", Details: []string{"Дизайн"}}
+	assert.EqualValues(t, teacher, ToTeacherInfo(FromTeacherInfo(teacher)))
+}
+
+func TestConvertChange(t *testing.T) {
+	date := time.Date(2024, 4, 16, 0, 0, 0, 0, time.UTC)
+	change := types.Change{Kind: types.Moved, WeekNumber: 11, WeekDayNum: 1, LessonNum: 2, Date: date,
+		PrevWeekDayNum: 0, PrevLessonNum: 3, PrevDate: date.AddDate(0, 0, -1),
+		Before: &types.SubLesson{Duration: 3, Name: "Философия", Room: "6-419"},
+		After:  &types.SubLesson{Duration: 2, Name: "Философия", Room: "6-419"}}
+	assert.EqualValues(t, change, ToChange(FromChange(change)))
+
+	added := types.Change{Kind: types.Added, After: &types.SubLesson{Name: "Правоведение"}}
+	pbAdded := FromChange(added)
+	assert.Nil(t, pbAdded.Before)
+	assert.Nil(t, pbAdded.Date)
+	assert.EqualValues(t, added, ToChange(pbAdded))
+
+	entity := types.Entity{Type: types.Teacher, Name: "Зенкина С М"}
+	assert.EqualValues(t, entity, ToEntity(FromEntity(entity)))
+}
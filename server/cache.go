@@ -0,0 +1,66 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// cacheEntry is the cached value with its expiration time.
+type cacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// cache keeps the values fetched from UlSTU site for the TTL.
+type cache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+}
+
+// newCache returns the empty *cache. The values are not cached if ttl is not positive.
+func newCache(ttl time.Duration) *cache {
+	return &cache{ttl: ttl, entries: map[string]cacheEntry{}}
+}
+
+// get returns the cached value by the key if it has not expired.
+func (c *cache) get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// set caches the value by the key.
+func (c *cache) set(key string, value interface{}) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = cacheEntry{value: value, expiresAt: time.Now().Add(c.ttl)}
+}
+
+// getOrLoad returns the cached value by the key, or loads and caches it. The errors are not cached.
+func (c *cache) getOrLoad(key string, load func() (interface{}, error)) (interface{}, error) {
+	if value, ok := c.get(key); ok {
+		return value, nil
+	}
+
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+	c.set(key, value)
+	return value, nil
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	t.Run("cached", func(t *testing.T) {
+		c := newCache(time.Minute)
+
+		loadsNum := 0
+		load := func() (interface{}, error) {
+			loadsNum++
+			return loadsNum, nil
+		}
+
+		value, err := c.getOrLoad("key", load)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, value)
+
+		value, err = c.getOrLoad("key", load)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, value)
+	})
+	t.Run("expired", func(t *testing.T) {
+		c := newCache(time.Nanosecond)
+		c.set("key", 1)
+		time.Sleep(time.Millisecond)
+
+		_, ok := c.get("key")
+		assert.False(t, ok)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		c := newCache(0)
+		c.set("key", 1)
+
+		_, ok := c.get("key")
+		assert.False(t, ok)
+	})
+	t.Run("errors are not cached", func(t *testing.T) {
+		c := newCache(time.Minute)
+		loadErr := errors.New("load failed")
+
+		_, err := c.getOrLoad("key", func() (interface{}, error) {
+			return nil, loadErr
+		})
+		assert.ErrorIs(t, err, loadErr)
+
+		_, ok := c.get("key")
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/ulstu-schedule/parser/schedule"
+	"github.com/ulstu-schedule/parser/storage"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+// Crawler downloads the schedules of all the groups and teachers, e.g. *schedule.Crawler.
+type Crawler interface {
+	Crawl(ctx context.Context) (*types.Snapshot, []*types.EntityError, error)
+}
+
+// CrawlAndSave downloads the schedules with the crawler, builds the room schedules from the group schedules and saves
+// all of them to the storage. The schedules that failed to download are not saved, they are returned as
+// *types.EntityError.
+func CrawlAndSave(ctx context.Context, crawler Crawler, store storage.Storage) ([]*types.EntityError, error) {
+	snapshot, entityErrs, err := crawler.Crawl(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule.AddRoomSchedules(snapshot)
+	if err = storage.SaveSnapshot(store, snapshot); err != nil {
+		return nil, err
+	}
+	return entityErrs, nil
+}
+
+// RunCrawler calls CrawlAndSave at once and then every interval until ctx is done. onCrawl is called after each
+// crawl with its results, it may be nil. Returns the error of ctx.
+func RunCrawler(ctx context.Context, crawler Crawler, store storage.Storage, interval time.Duration,
+	onCrawl func(entityErrs []*types.EntityError, err error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		entityErrs, err := CrawlAndSave(ctx, crawler, store)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if onCrawl != nil {
+			onCrawl(entityErrs, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
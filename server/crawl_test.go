@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/mock"
+	"github.com/ulstu-schedule/parser/storage"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+// testCrawler returns the snapshot with the schedules of the mock group and the errors of the other entities.
+type testCrawler struct {
+	t         *testing.T
+	crawlsNum int
+	err       error
+}
+
+func (c *testCrawler) Crawl(context.Context) (*types.Snapshot, []*types.EntityError, error) {
+	c.crawlsNum++
+	if c.err != nil {
+		return nil, nil, c.err
+	}
+
+	snapshot := types.NewSnapshot()
+	snapshot.Add(types.Entity{Type: types.Group, Name: "АТсд-21"}, mock.TestGroupSchedule(c.t),
+		time.Date(2024, 4, 16, 9, 0, 0, 0, time.UTC))
+
+	entityErr := &types.EntityError{Entity: types.Entity{Type: types.Group, Name: "ПИбд-11"},
+		Err: &types.UnavailableScheduleError{Name: "ПИбд-11", Scope: types.FullScope}}
+	return snapshot, []*types.EntityError{entityErr}, nil
+}
+
+func TestCrawlAndSave(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	s, _ := newTestServer(t, store)
+
+	entityErrs, err := CrawlAndSave(context.Background(), &testCrawler{t: t}, store)
+	if !assert.NoError(t, err) {
+		return
+	}
+	if assert.Len(t, entityErrs, 1) {
+		assert.EqualValues(t, "ПИбд-11", entityErrs[0].Entity.Name)
+	}
+
+	_, fetchedAt, err := store.Load(types.Entity{Type: types.Group, Name: "АТсд-21"}, time.Time{})
+	assert.NoError(t, err)
+	assert.EqualValues(t, time.Date(2024, 4, 16, 9, 0, 0, 0, time.UTC), fetchedAt)
+
+	// the room endpoints serve the schedules built from the saved group schedules
+	rec := doTestRequest(s, "/rooms")
+	rooms := make([]string, 0)
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rooms))
+	assert.Contains(t, rooms, "6-401")
+
+	rec = doTestRequest(s, "/rooms/6-401/week?date=2024-04-16")
+	assert.EqualValues(t, http.StatusOK, rec.Code)
+
+	crawlErr := errors.New("site is unavailable")
+	_, err = CrawlAndSave(context.Background(), &testCrawler{t: t, err: crawlErr}, storage.NewMemoryStorage())
+	assert.ErrorIs(t, err, crawlErr)
+}
+
+func TestRunCrawler(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	crawler := &testCrawler{t: t}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	crawlsNum := 0
+	err := RunCrawler(ctx, crawler, store, time.Millisecond, func(entityErrs []*types.EntityError, err error) {
+		assert.NoError(t, err)
+		assert.Len(t, entityErrs, 1)
+
+		crawlsNum++
+		if crawlsNum == 2 {
+			cancel()
+		}
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.EqualValues(t, 2, crawler.crawlsNum)
+
+	entities, err := store.Entities()
+	assert.NoError(t, err)
+	assert.Contains(t, entities, types.Entity{Type: types.Room, Name: "6-401"})
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ulstu-schedule/parser/types"
+)
+
+// errorResponse is the JSON body of the response with the error.
+type errorResponse struct {
+	Error       string   `json:"error"` // code of the error
+	Message     string   `json:"message"`
+	Suggestions []string `json:"suggestions,omitempty"` // the nearest existing names if the entity is not found
+}
+
+// newErrorResponse returns the status code and the body of the response with the error.
+func newErrorResponse(err error) (int, errorResponse) {
+	res := errorResponse{Message: err.Error()}
+
+	var (
+		notFoundErr      *types.NotFoundError
+		incorrectDateErr *types.IncorrectDateError
+		incorrectSubErr  *types.IncorrectSubGroupError
+		incorrectWeekErr *types.IncorrectWeekNumberError
+		incorrectLinkErr *types.IncorrectLinkError
+	)
+
+	switch {
+	case errors.As(err, &notFoundErr):
+		res.Error, res.Suggestions = "not_found", notFoundErr.Suggestions
+		return http.StatusNotFound, res
+	case errors.Is(err, types.ErrNotFound), errors.Is(err, types.ErrNotStored):
+		res.Error = "not_found"
+		return http.StatusNotFound, res
+	case errors.Is(err, types.ErrNotPublished):
+		res.Error = "not_published"
+		return http.StatusNotFound, res
+	case errors.As(err, &incorrectDateErr), errors.As(err, &incorrectWeekErr), errors.As(err, &incorrectSubErr):
+		res.Error = "bad_request"
+		return http.StatusBadRequest, res
+	case errors.Is(err, types.ErrSiteUnavailable):
+		res.Error = "site_unavailable"
+		return http.StatusBadGateway, res
+	case errors.Is(err, types.ErrLayoutChanged), errors.As(err, &incorrectLinkErr):
+		res.Error = "layout_changed"
+		return http.StatusBadGateway, res
+	default:
+		res.Error = "internal"
+		return http.StatusInternalServerError, res
+	}
+}
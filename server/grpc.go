@@ -0,0 +1,254 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ulstu-schedule/parser/schedule"
+	"github.com/ulstu-schedule/parser/schedulepb"
+	"github.com/ulstu-schedule/parser/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// watchBufferSize is the number of the change events buffered for the stream of WatchChanges. The events are dropped
+// if the client does not read them.
+const watchBufferSize = 16
+
+// grpcCodes maps the error codes of the HTTP API to the gRPC status codes.
+var grpcCodes = map[string]codes.Code{
+	"not_found":        codes.NotFound,
+	"not_published":    codes.NotFound,
+	"bad_request":      codes.InvalidArgument,
+	"site_unavailable": codes.Unavailable,
+	"layout_changed":   codes.Internal,
+	"internal":         codes.Internal,
+}
+
+// GRPCService implements schedulepb.ScheduleServiceServer on top of the Server, so both APIs share the cache and the
+// storage. The changes are streamed by WatchChanges if the service has the watcher, Notify must be set as its
+// OnChange.
+type GRPCService struct {
+	schedulepb.UnimplementedScheduleServiceServer
+
+	server  *Server
+	watcher *schedule.Watcher
+
+	mu sync.Mutex
+	// watches contains the channels of the events of the active WatchChanges streams
+	watches map[chan schedule.ChangeEvent]map[types.Entity]bool
+	// watchesNum is the number of the active streams that watch the entity
+	watchesNum map[types.Entity]int
+}
+
+// NewGRPCService returns *GRPCService that serves the schedules of s. The watcher may be nil, then WatchChanges is not
+// available.
+func NewGRPCService(s *Server, watcher *schedule.Watcher) *GRPCService {
+	return &GRPCService{
+		server:     s,
+		watcher:    watcher,
+		watches:    map[chan schedule.ChangeEvent]map[types.Entity]bool{},
+		watchesNum: map[types.Entity]int{},
+	}
+}
+
+// GetSchedule returns the full schedule of the group, teacher or room.
+func (g *GRPCService) GetSchedule(_ context.Context, req *schedulepb.GetScheduleRequest) (
+	*schedulepb.GetScheduleResponse, error) {
+	if req.GetEntity().GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "the name of the entity is required")
+	}
+
+	if req.GetSubGroup() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "the subgroup must not be negative")
+	}
+
+	result, err := g.server.getSchedule(schedulepb.ToEntity(req.GetEntity()))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	fullSchedule := result.schedule
+	if req.GetSubGroup() > 0 {
+		fullSchedule = schedule.FilterSubGroup(fullSchedule, int(req.GetSubGroup()))
+	}
+
+	res := &schedulepb.GetScheduleResponse{Schedule: schedulepb.FromSchedule(fullSchedule), Stale: result.isStale}
+	if !result.fetchedAt.IsZero() {
+		res.FetchedAt = timestamppb.New(result.fetchedAt)
+	}
+	return res, nil
+}
+
+// ListGroups returns the groups from the lists of groups on UlSTU site.
+func (g *GRPCService) ListGroups(context.Context, *schedulepb.ListGroupsRequest) (*schedulepb.ListGroupsResponse,
+	error) {
+	groups, err := g.server.getGroups()
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	res := &schedulepb.ListGroupsResponse{Groups: make([]*schedulepb.GroupInfo, 0, len(groups))}
+	for _, group := range groups {
+		res.Groups = append(res.Groups, schedulepb.FromGroupInfo(group))
+	}
+	return res, nil
+}
+
+// ListTeachers returns the teachers from the list of teachers on UlSTU site.
+func (g *GRPCService) ListTeachers(context.Context, *schedulepb.ListTeachersRequest) (
+	*schedulepb.ListTeachersResponse, error) {
+	teachers, err := g.server.getTeachers()
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	res := &schedulepb.ListTeachersResponse{Teachers: make([]*schedulepb.TeacherInfo, 0, len(teachers))}
+	for _, teacher := range teachers {
+		res.Teachers = append(res.Teachers, schedulepb.FromTeacherInfo(teacher))
+	}
+	return res, nil
+}
+
+// FindFreeRooms returns the rooms from the storage that have no lessons in the time slot on the date. The date is
+// taken in the time zone of the server.
+func (g *GRPCService) FindFreeRooms(_ context.Context, req *schedulepb.FindFreeRoomsRequest) (
+	*schedulepb.FindFreeRoomsResponse, error) {
+	if req.GetLessonNum() < 0 || int(req.GetLessonNum()) >= len(types.DefaultTimeTable.Slots) {
+		return nil, status.Errorf(codes.InvalidArgument, "the lesson number must be from 0 to %d",
+			len(types.DefaultTimeTable.Slots)-1)
+	}
+
+	date := g.server.now()
+	if req.GetDate() != nil {
+		date = req.GetDate().AsTime().In(time.Local)
+	}
+	year, month, day := date.Date()
+	date = time.Date(year, month, day, 12, 0, 0, 0, time.UTC)
+
+	rooms, err := g.server.getRooms()
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	roomSchedules := map[string]*types.Schedule{}
+	for _, room := range rooms {
+		if !strings.HasPrefix(room, req.GetPrefix()) {
+			continue
+		}
+
+		result, err := g.server.loadSchedule(types.Entity{Type: types.Room, Name: room})
+		if err != nil {
+			return nil, toStatusError(err)
+		}
+		roomSchedules[room] = result.schedule
+	}
+
+	return &schedulepb.FindFreeRoomsResponse{
+		Rooms: schedule.FindFreeRooms(roomSchedules, date, int(req.GetLessonNum())),
+	}, nil
+}
+
+// WatchChanges subscribes the watcher to the schedules of the groups and teachers and streams their changes until the
+// client cancels the call. The schedules are unsubscribed when no stream watches them.
+func (g *GRPCService) WatchChanges(req *schedulepb.WatchChangesRequest,
+	stream schedulepb.ScheduleService_WatchChangesServer) error {
+	if g.watcher == nil {
+		return status.Error(codes.Unimplemented, "the changes are not watched by the server")
+	}
+
+	entities := map[types.Entity]bool{}
+	for _, pbEntity := range req.GetEntities() {
+		entity := schedulepb.ToEntity(pbEntity)
+		if entity.Type == types.Room || entity.Name == "" {
+			return status.Errorf(codes.InvalidArgument, "only the changes of the groups and teachers can be watched, "+
+				"got %s", entity)
+		}
+		entities[entity] = true
+	}
+	if len(entities) == 0 {
+		return status.Error(codes.InvalidArgument, "no entities to watch")
+	}
+
+	events := g.addWatch(entities)
+	defer g.removeWatch(events)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event := <-events:
+			pbEvent := &schedulepb.ChangeEvent{
+				Entity:    schedulepb.FromEntity(event.Entity),
+				Changes:   make([]*schedulepb.Change, 0, len(event.Changes)),
+				FetchedAt: timestamppb.New(event.FetchedAt),
+			}
+			for _, change := range event.Changes {
+				pbEvent.Changes = append(pbEvent.Changes, schedulepb.FromChange(change))
+			}
+
+			if err := stream.Send(pbEvent); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Notify sends the change event to the WatchChanges streams that watch its entity.
+func (g *GRPCService) Notify(event schedule.ChangeEvent) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for events, entities := range g.watches {
+		if !entities[event.Entity] {
+			continue
+		}
+
+		select {
+		case events <- event:
+		default:
+		}
+	}
+}
+
+// addWatch returns the channel of the events of the entities and subscribes the watcher to them.
+func (g *GRPCService) addWatch(entities map[types.Entity]bool) chan schedule.ChangeEvent {
+	events := make(chan schedule.ChangeEvent, watchBufferSize)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.watches[events] = entities
+	for entity := range entities {
+		if g.watchesNum[entity] == 0 {
+			g.watcher.Subscribe(entity)
+		}
+		g.watchesNum[entity]++
+	}
+	return events
+}
+
+// removeWatch removes the channel of the events and unsubscribes the watcher from the entities that are not watched
+// anymore.
+func (g *GRPCService) removeWatch(events chan schedule.ChangeEvent) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for entity := range g.watches[events] {
+		g.watchesNum[entity]--
+		if g.watchesNum[entity] == 0 {
+			delete(g.watchesNum, entity)
+			g.watcher.Unsubscribe(entity)
+		}
+	}
+	delete(g.watches, events)
+}
+
+// toStatusError returns the gRPC status error with the code that matches the error.
+func toStatusError(err error) error {
+	_, res := newErrorResponse(err)
+	return status.Error(grpcCodes[res.Error], res.Message)
+}
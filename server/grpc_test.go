@@ -0,0 +1,171 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/mock"
+	"github.com/ulstu-schedule/parser/schedule"
+	"github.com/ulstu-schedule/parser/schedulepb"
+	"github.com/ulstu-schedule/parser/storage"
+	"github.com/ulstu-schedule/parser/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newTestGRPCClient(t *testing.T, service *GRPCService) schedulepb.ScheduleServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	schedulepb.RegisterScheduleServiceServer(grpcServer, service)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.Dial("bufnet", grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}))
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return schedulepb.NewScheduleServiceClient(conn)
+}
+
+func TestGRPCService(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	fetchedAt := time.Date(2024, 4, 15, 8, 0, 0, 0, time.UTC)
+	assert.NoError(t, store.Save(types.Entity{Type: types.Group, Name: "ИСТбд-11"}, mock.TestGroupSchedule(t),
+		fetchedAt))
+	assert.NoError(t, store.Save(types.Entity{Type: types.Room, Name: "6-401"}, mock.TestRoomSchedule(t), fetchedAt))
+	assert.NoError(t, store.Save(types.Entity{Type: types.Room, Name: "6-402"}, mock.TestGroupSchedule(t), fetchedAt))
+	assert.NoError(t, store.Save(types.Entity{Type: types.Room, Name: "3-101"}, mock.TestGroupSchedule(t), fetchedAt))
+
+	s, _ := newTestServer(t, store)
+	client := newTestGRPCClient(t, NewGRPCService(s, nil))
+	ctx := context.Background()
+
+	t.Run("schedule", func(t *testing.T) {
+		res, err := client.GetSchedule(ctx, &schedulepb.GetScheduleRequest{
+			Entity: &schedulepb.Entity{Type: schedulepb.ScheduleType_SCHEDULE_TYPE_GROUP, Name: "АТсд-21"}})
+		assert.NoError(t, err)
+		assert.Len(t, res.Schedule.Weeks, 2)
+		assert.EqualValues(t, 11, res.Schedule.Weeks[0].Number)
+		assert.False(t, res.Stale)
+		assert.Nil(t, res.FetchedAt)
+	})
+	t.Run("subgroup", func(t *testing.T) {
+		res, err := client.GetSchedule(ctx, &schedulepb.GetScheduleRequest{
+			Entity:   &schedulepb.Entity{Type: schedulepb.ScheduleType_SCHEDULE_TYPE_GROUP, Name: "АТсд-21"},
+			SubGroup: 2,
+		})
+		assert.NoError(t, err)
+		monday := res.Schedule.Weeks[0].Days[0]
+		assert.Empty(t, monday.Lessons[1].SubLessons)
+		if assert.Len(t, monday.Lessons[2].SubLessons, 1) {
+			assert.EqualValues(t, "2 п/г", monday.Lessons[2].SubLessons[0].SubGroup)
+		}
+	})
+	t.Run("stale schedule", func(t *testing.T) {
+		res, err := client.GetSchedule(ctx, &schedulepb.GetScheduleRequest{
+			Entity: &schedulepb.Entity{Type: schedulepb.ScheduleType_SCHEDULE_TYPE_GROUP, Name: "ИСТбд-11"}})
+		assert.NoError(t, err)
+		assert.True(t, res.Stale)
+		assert.True(t, fetchedAt.Equal(res.FetchedAt.AsTime()))
+	})
+	t.Run("lists", func(t *testing.T) {
+		groups, err := client.ListGroups(ctx, &schedulepb.ListGroupsRequest{})
+		assert.NoError(t, err)
+		assert.Len(t, groups.Groups, 1)
+		assert.EqualValues(t, "АТсд-21", groups.Groups[0].Name)
+
+		teachers, err := client.ListTeachers(ctx, &schedulepb.ListTeachersRequest{})
+		assert.NoError(t, err)
+		assert.Len(t, teachers.Teachers, 1)
+		assert.EqualValues(t, "Зенкина С М", teachers.Teachers[0].Name)
+	})
+	t.Run("free rooms", func(t *testing.T) {
+		res, err := client.FindFreeRooms(ctx, &schedulepb.FindFreeRoomsRequest{LessonNum: 6, Prefix: "6-"})
+		assert.NoError(t, err)
+		assert.EqualValues(t, []string{"6-402"}, res.Rooms)
+
+		res, err = client.FindFreeRooms(ctx, &schedulepb.FindFreeRoomsRequest{LessonNum: 6,
+			Date: timestamppb.New(time.Date(2024, 4, 23, 12, 0, 0, 0, time.UTC))})
+		assert.NoError(t, err)
+		assert.EqualValues(t, []string{"3-101", "6-402"}, res.Rooms)
+	})
+	t.Run("errors", func(t *testing.T) {
+		_, err := client.GetSchedule(ctx, &schedulepb.GetScheduleRequest{
+			Entity: &schedulepb.Entity{Type: schedulepb.ScheduleType_SCHEDULE_TYPE_GROUP, Name: "ПИбд-99"}})
+		assert.EqualValues(t, codes.NotFound, status.Code(err))
+
+		_, err = client.GetSchedule(ctx, &schedulepb.GetScheduleRequest{})
+		assert.EqualValues(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = client.GetSchedule(ctx, &schedulepb.GetScheduleRequest{
+			Entity:   &schedulepb.Entity{Type: schedulepb.ScheduleType_SCHEDULE_TYPE_GROUP, Name: "АТсд-21"},
+			SubGroup: -1,
+		})
+		assert.EqualValues(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = client.FindFreeRooms(ctx, &schedulepb.FindFreeRoomsRequest{LessonNum: 8})
+		assert.EqualValues(t, codes.InvalidArgument, status.Code(err))
+
+		stream, err := client.WatchChanges(ctx, &schedulepb.WatchChangesRequest{})
+		assert.NoError(t, err)
+		_, err = stream.Recv()
+		assert.EqualValues(t, codes.Unimplemented, status.Code(err))
+	})
+}
+
+func TestGRPCServiceWatchChanges(t *testing.T) {
+	s, _ := newTestServer(t, nil)
+	service := NewGRPCService(s, schedule.NewWatcher(storage.NewMemoryStorage(), time.Hour))
+	client := newTestGRPCClient(t, service)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	group := types.Entity{Type: types.Group, Name: "АТсд-21"}
+	stream, err := client.WatchChanges(ctx, &schedulepb.WatchChangesRequest{
+		Entities: []*schedulepb.Entity{schedulepb.FromEntity(group)}})
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		service.mu.Lock()
+		defer service.mu.Unlock()
+		return service.watchesNum[group] == 1
+	}, time.Second, 10*time.Millisecond)
+
+	fetchedAt := time.Date(2024, 4, 16, 10, 0, 0, 0, time.UTC)
+	service.Notify(schedule.ChangeEvent{Entity: types.Entity{Type: types.Group, Name: "ПИбд-11"},
+		Changes: []types.Change{{Kind: types.Added}}})
+	service.Notify(schedule.ChangeEvent{Entity: group, FetchedAt: fetchedAt, Changes: []types.Change{{
+		Kind: types.RoomChanged, WeekNumber: 11, Before: &types.SubLesson{Room: "6-419"},
+		After: &types.SubLesson{Room: "6-420"}}}})
+
+	event, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.EqualValues(t, group, schedulepb.ToEntity(event.Entity))
+	assert.True(t, fetchedAt.Equal(event.FetchedAt.AsTime()))
+	assert.Len(t, event.Changes, 1)
+	assert.EqualValues(t, schedulepb.ChangeKind_CHANGE_KIND_ROOM_CHANGED, event.Changes[0].Kind)
+	assert.EqualValues(t, "6-420", event.Changes[0].After.Room)
+
+	cancel()
+	assert.Eventually(t, func() bool {
+		service.mu.Lock()
+		defer service.mu.Unlock()
+		return len(service.watches) == 0 && len(service.watchesNum) == 0
+	}, time.Second, 10*time.Millisecond)
+}
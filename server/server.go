@@ -0,0 +1,381 @@
+// Package server implements the HTTP REST API and the gRPC service for the schedules of the groups, teachers and
+// rooms.
+package server
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mailru/easyjson"
+	"github.com/ulstu-schedule/parser/schedule"
+	"github.com/ulstu-schedule/parser/storage"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+const (
+	// StaleHeader is set to "true" if the schedule was loaded from the storage because UlSTU site is unavailable
+	StaleHeader = "X-Schedule-Stale"
+	// FetchedAtHeader contains the fetch time of the schedule loaded from the storage
+	FetchedAtHeader = "X-Schedule-Fetched-At"
+
+	dateParam     = "date"
+	dateFormat    = "2006-01-02"
+	subGroupParam = "subgroup"
+)
+
+//go:embed openapi.yaml
+var openAPISpec []byte
+
+// resourceTypes maps the first segment of the path to the type of the schedule.
+var resourceTypes = map[string]types.ScheduleType{"groups": types.Group, "teachers": types.Teacher,
+	"rooms": types.Room}
+
+// Server serves the schedules from UlSTU site as JSON, PNG images and iCalendar files:
+//
+//	GET /groups, /teachers, /rooms
+//	GET /{groups|teachers|rooms}/{name}/schedule
+//	GET /{groups|teachers|rooms}/{name}/week?date=2006-01-02
+//	GET /{groups|teachers|rooms}/{name}/day?date=2006-01-02
+//	GET /{groups|teachers|rooms}/{name}/image.png?date=2006-01-02
+//	GET /{groups|teachers|rooms}/{name}/calendar.ics
+//	GET /openapi.yaml
+//
+// The schedule endpoints accept the subgroup query parameter, e.g. ?subgroup=2, that leaves only the lessons of the
+// subgroup and the lessons common for the group.
+//
+// The schedules fetched from the site are cached. The storage is used to serve the room schedules, and the group
+// and teacher schedules when the site is unavailable. The storage is filled by CrawlAndSave or RunCrawler, the
+// requests to the server do not write to it.
+type Server struct {
+	store storage.Storage
+	cache *cache
+	now   func() time.Time
+
+	listGroups   func() ([]types.GroupInfo, error)
+	listTeachers func() ([]types.TeacherInfo, error)
+	fetch        func(entity types.Entity) (*types.Schedule, error)
+}
+
+// scheduleResult is the schedule loaded by the server.
+type scheduleResult struct {
+	schedule  *types.Schedule
+	isStale   bool
+	fetchedAt time.Time
+}
+
+// NewServer returns *Server that caches the schedules for cacheTTL. The store may be nil, then the room schedules
+// are not available.
+func NewServer(store storage.Storage, cacheTTL time.Duration) *Server {
+	return &Server{
+		store:        store,
+		cache:        newCache(cacheTTL),
+		now:          time.Now,
+		listGroups:   schedule.GetGroupsInfo,
+		listTeachers: schedule.GetTeachersInfo,
+		fetch:        schedule.GetFullEntitySchedule,
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method_not_allowed",
+			Message: "only GET requests are supported"})
+		return
+	}
+
+	segments, err := splitPath(r.URL.EscapedPath())
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "bad_request", Message: err.Error()})
+		return
+	}
+
+	if len(segments) == 1 && segments[0] == "openapi.yaml" {
+		w.Header().Set("Content-Type", "application/yaml")
+		_, _ = w.Write(openAPISpec)
+		return
+	}
+
+	if len(segments) == 0 {
+		writeNotFound(w)
+		return
+	}
+	typeSchedule, ok := resourceTypes[segments[0]]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	switch len(segments) {
+	case 1:
+		s.handleList(w, typeSchedule)
+	case 3:
+		s.handleSchedule(w, r, types.Entity{Type: typeSchedule, Name: segments[1]}, segments[2])
+	default:
+		writeNotFound(w)
+	}
+}
+
+// handleList writes the list of the groups, teachers or rooms.
+func (s *Server) handleList(w http.ResponseWriter, typeSchedule types.ScheduleType) {
+	var (
+		list interface{}
+		err  error
+	)
+
+	switch typeSchedule {
+	case types.Group:
+		list, err = s.getGroups()
+	case types.Teacher:
+		list, err = s.getTeachers()
+	default:
+		list, err = s.getRooms()
+	}
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// handleSchedule writes the schedule of the entity in the format requested by the action.
+func (s *Server) handleSchedule(w http.ResponseWriter, r *http.Request, entity types.Entity, action string) {
+	switch action {
+	case "schedule", "week", "day", "image.png", "calendar.ics":
+	default:
+		writeNotFound(w)
+		return
+	}
+
+	date, err := s.getDate(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	subGroup, err := getSubGroup(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	result, err := s.getSchedule(entity)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	fullSchedule := result.schedule
+	if subGroup > 0 {
+		fullSchedule = schedule.FilterSubGroup(fullSchedule, subGroup)
+	}
+	if result.isStale {
+		w.Header().Set(StaleHeader, "true")
+	}
+	if !result.fetchedAt.IsZero() {
+		w.Header().Set(FetchedAtHeader, result.fetchedAt.UTC().Format(time.RFC3339))
+	}
+
+	switch action {
+	case "schedule":
+		data, err := easyjson.Marshal(fullSchedule)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = w.Write(data)
+	case "week":
+		week, err := schedule.ParseWeekScheduleByDate(fullSchedule, entity.Name, date)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, week)
+	case "day":
+		day, err := schedule.ParseDayScheduleByDate(fullSchedule, entity.Name, date)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, day)
+	case "image.png":
+		s.writeWeekImage(w, fullSchedule, entity, date)
+	case "calendar.ics":
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		_, _ = w.Write([]byte(schedule.ConvertScheduleToICS(fullSchedule, entity.Name, entity.Type)))
+	}
+}
+
+// writeWeekImage writes the PNG image with the week schedule of the date.
+func (s *Server) writeWeekImage(w http.ResponseWriter, fullSchedule *types.Schedule, entity types.Entity,
+	date time.Time) {
+	week, err := schedule.ParseWeekScheduleByDate(fullSchedule, entity.Name, date)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	currYear, currWeek := s.now().ISOWeek()
+	year, weekNum := date.ISOWeek()
+	img, err := schedule.RenderWeekScheduleImg(week, entity.Name, entity.Type, year == currYear && weekNum == currWeek)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "image/png")
+	_, _ = w.Write(img)
+}
+
+// getSchedule returns the schedule of the entity. The room schedules are loaded from the storage, the group and
+// teacher schedules are fetched from UlSTU site and loaded from the storage only if the site is unavailable.
+func (s *Server) getSchedule(entity types.Entity) (*scheduleResult, error) {
+	if entity.Type == types.Room {
+		return s.loadSchedule(entity)
+	}
+
+	cacheKey := "schedule:" + entity.String()
+	value, err := s.cache.getOrLoad(cacheKey, func() (interface{}, error) {
+		fullSchedule, err := s.fetch(entity)
+		if err != nil {
+			return nil, err
+		}
+		return &scheduleResult{schedule: fullSchedule}, nil
+	})
+
+	if errors.Is(err, types.ErrSiteUnavailable) && s.store != nil {
+		if result, loadErr := s.loadSchedule(entity); loadErr == nil {
+			result.isStale = true
+			return result, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return value.(*scheduleResult), nil
+}
+
+// loadSchedule returns the latest schedule of the entity from the storage.
+func (s *Server) loadSchedule(entity types.Entity) (*scheduleResult, error) {
+	if s.store == nil {
+		return nil, &types.NotStoredError{Entity: entity}
+	}
+
+	fullSchedule, fetchedAt, err := s.store.Load(entity, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	return &scheduleResult{schedule: fullSchedule, fetchedAt: fetchedAt}, nil
+}
+
+// getGroups returns the cached list of the groups.
+func (s *Server) getGroups() ([]types.GroupInfo, error) {
+	groups, err := s.cache.getOrLoad("groups", func() (interface{}, error) {
+		return s.listGroups()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return groups.([]types.GroupInfo), nil
+}
+
+// getTeachers returns the cached list of the teachers.
+func (s *Server) getTeachers() ([]types.TeacherInfo, error) {
+	teachers, err := s.cache.getOrLoad("teachers", func() (interface{}, error) {
+		return s.listTeachers()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return teachers.([]types.TeacherInfo), nil
+}
+
+// getRooms returns the names of the rooms which schedules are in the storage.
+func (s *Server) getRooms() ([]string, error) {
+	rooms := make([]string, 0)
+	if s.store == nil {
+		return rooms, nil
+	}
+
+	entities, err := s.store.Entities()
+	if err != nil {
+		return nil, err
+	}
+	for _, entity := range entities {
+		if entity.Type == types.Room {
+			rooms = append(rooms, entity.Name)
+		}
+	}
+	return rooms, nil
+}
+
+// getDate returns the date from the query of the request, or the current date if it is not set. The noon of the date
+// is returned, so it is inside the school week regardless of the time zone of the week dates.
+func (s *Server) getDate(r *http.Request) (time.Time, error) {
+	dateStr := r.URL.Query().Get(dateParam)
+	if dateStr == "" {
+		year, month, day := s.now().Date()
+		return time.Date(year, month, day, 12, 0, 0, 0, time.UTC), nil
+	}
+
+	date, err := time.Parse(dateFormat, dateStr)
+	if err != nil {
+		return time.Time{}, &types.IncorrectDateError{Date: dateStr}
+	}
+	return date.Add(12 * time.Hour), nil
+}
+
+// getSubGroup returns the number of the subgroup from the query of the request, or 0 if it is not set.
+func getSubGroup(r *http.Request) (int, error) {
+	subGroupStr := r.URL.Query().Get(subGroupParam)
+	if subGroupStr == "" {
+		return 0, nil
+	}
+
+	subGroup, err := strconv.Atoi(subGroupStr)
+	if err != nil || subGroup <= 0 {
+		return 0, &types.IncorrectSubGroupError{SubGroup: subGroupStr}
+	}
+	return subGroup, nil
+}
+
+// splitPath returns the unescaped segments of the escaped path. The segments are unescaped separately, so the names
+// may contain the escaped slashes.
+func splitPath(escapedPath string) ([]string, error) {
+	segments := make([]string, 0)
+	for _, segment := range strings.Split(strings.Trim(escapedPath, "/"), "/") {
+		if segment == "" {
+			continue
+		}
+
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, unescaped)
+	}
+	return segments, nil
+}
+
+// writeJSON writes the value as JSON with the status code.
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes the error as JSON with the status code that matches the error.
+func writeError(w http.ResponseWriter, err error) {
+	statusCode, res := newErrorResponse(err)
+	writeJSON(w, statusCode, res)
+}
+
+// writeNotFound writes the error about the unknown path.
+func writeNotFound(w http.ResponseWriter) {
+	writeJSON(w, http.StatusNotFound, errorResponse{Error: "not_found", Message: "unknown path"})
+}
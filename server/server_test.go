@@ -0,0 +1,228 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mailru/easyjson"
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/mock"
+	"github.com/ulstu-schedule/parser/storage"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+func newTestServer(t *testing.T, store storage.Storage) (*Server, *int) {
+	t.Helper()
+
+	s := NewServer(store, time.Minute)
+	s.now = func() time.Time {
+		return time.Date(2024, 4, 16, 10, 0, 0, 0, time.UTC)
+	}
+	s.listGroups = func() ([]types.GroupInfo, error) {
+		return []types.GroupInfo{{Name: "АТсд-21", Course: 2}}, nil
+	}
+	s.listTeachers = func() ([]types.TeacherInfo, error) {
+		return []types.TeacherInfo{{Name: "Зенкина С М"}}, nil
+	}
+
+	fetchesNum := 0
+	s.fetch = func(entity types.Entity) (*types.Schedule, error) {
+		fetchesNum++
+		switch entity {
+		case types.Entity{Type: types.Group, Name: "АТсд-21"}:
+			return mock.TestGroupSchedule(t), nil
+		case types.Entity{Type: types.Teacher, Name: "Зенкина С М"}:
+			return mock.TestTeacherSchedule(t), nil
+		case types.Entity{Type: types.Group, Name: "ПИбд-11"}:
+			return nil, &types.UnavailableScheduleError{Name: entity.Name, Scope: types.FullScope}
+		case types.Entity{Type: types.Group, Name: "ИСТбд-11"}:
+			return nil, &types.RequestError{URL: "https://lk.ulstu.ru", Err: http.ErrHandlerTimeout}
+		default:
+			return nil, &types.NotFoundError{Name: entity.Name, Type: entity.Type, Suggestions: []string{"АТсд-21"}}
+		}
+	}
+	return s, &fetchesNum
+}
+
+func doTestRequest(s *Server, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestServerLists(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	assert.NoError(t, store.Save(types.Entity{Type: types.Room, Name: "6-401"}, &types.Schedule{}, time.Now()))
+	s, _ := newTestServer(t, store)
+
+	rec := doTestRequest(s, "/groups")
+	assert.EqualValues(t, http.StatusOK, rec.Code)
+	var groups []types.GroupInfo
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &groups))
+	assert.EqualValues(t, []types.GroupInfo{{Name: "АТсд-21", Course: 2}}, groups)
+
+	rec = doTestRequest(s, "/teachers")
+	assert.EqualValues(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"name":"Зенкина С М"`)
+
+	rec = doTestRequest(s, "/rooms")
+	assert.EqualValues(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `["6-401"]`, rec.Body.String())
+}
+
+func TestServerSchedule(t *testing.T) {
+	s, fetchesNum := newTestServer(t, nil)
+
+	t.Run("full schedule", func(t *testing.T) {
+		rec := doTestRequest(s, "/groups/"+url.PathEscape("АТсд-21")+"/schedule")
+		assert.EqualValues(t, http.StatusOK, rec.Code)
+
+		schedule := &types.Schedule{}
+		assert.NoError(t, easyjson.Unmarshal(rec.Body.Bytes(), schedule))
+		assert.EqualValues(t, mock.TestGroupSchedule(t), schedule)
+	})
+	t.Run("cached", func(t *testing.T) {
+		doTestRequest(s, "/groups/"+url.PathEscape("АТсд-21")+"/week")
+		assert.EqualValues(t, 1, *fetchesNum)
+	})
+	t.Run("week", func(t *testing.T) {
+		rec := doTestRequest(s, "/groups/"+url.PathEscape("АТсд-21")+"/week?date=2024-04-23")
+		assert.EqualValues(t, http.StatusOK, rec.Code)
+
+		var week types.Week
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &week))
+		assert.EqualValues(t, 12, week.Number)
+	})
+	t.Run("day", func(t *testing.T) {
+		rec := doTestRequest(s, "/teachers/"+url.PathEscape("Зенкина С М")+"/day?date=2024-04-10")
+		assert.EqualValues(t, http.StatusOK, rec.Code)
+
+		var day types.Day
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &day))
+		assert.EqualValues(t, "СОбд-21", day.Lessons[1].SubLessons[0].Group)
+	})
+	t.Run("subgroup", func(t *testing.T) {
+		rec := doTestRequest(s, "/groups/"+url.PathEscape("АТсд-21")+"/day?date=2024-04-15&subgroup=2")
+		assert.EqualValues(t, http.StatusOK, rec.Code)
+
+		var day types.Day
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &day))
+		assert.Empty(t, day.Lessons[1].SubLessons)
+		assert.EqualValues(t, "2 п/г", day.Lessons[2].SubLessons[0].SubGroup)
+	})
+	t.Run("image", func(t *testing.T) {
+		// the image is rendered in memory, nothing is written to the working directory
+		workDir := mock.ChdirTemp(t)
+
+		rec := doTestRequest(s, "/groups/"+url.PathEscape("АТсд-21")+"/image.png")
+		assert.EqualValues(t, http.StatusOK, rec.Code)
+		assert.EqualValues(t, "image/png", rec.Header().Get("Content-Type"))
+		assert.True(t, bytes.HasPrefix(rec.Body.Bytes(), []byte("\x89PNG")))
+
+		workDirFiles, err := ioutil.ReadDir(workDir)
+		assert.NoError(t, err)
+		assert.Empty(t, workDirFiles)
+	})
+	t.Run("calendar", func(t *testing.T) {
+		rec := doTestRequest(s, "/groups/"+url.PathEscape("АТсд-21")+"/calendar.ics")
+		assert.EqualValues(t, http.StatusOK, rec.Code)
+		assert.True(t, strings.HasPrefix(rec.Body.String(), "BEGIN:VCALENDAR"))
+	})
+}
+
+func TestServerErrors(t *testing.T) {
+	s, _ := newTestServer(t, nil)
+
+	testCases := []struct {
+		name       string
+		path       string
+		statusCode int
+		errCode    string
+	}{
+		{name: "unknown group", path: "/groups/atsd/schedule", statusCode: http.StatusNotFound, errCode: "not_found"},
+		{name: "not published", path: "/groups/" + url.PathEscape("ПИбд-11") + "/schedule",
+			statusCode: http.StatusNotFound, errCode: "not_published"},
+		{name: "site unavailable", path: "/groups/" + url.PathEscape("ИСТбд-11") + "/schedule",
+			statusCode: http.StatusBadGateway, errCode: "site_unavailable"},
+		{name: "incorrect date", path: "/groups/" + url.PathEscape("АТсд-21") + "/day?date=16.04",
+			statusCode: http.StatusBadRequest, errCode: "bad_request"},
+		{name: "incorrect subgroup", path: "/groups/" + url.PathEscape("АТсд-21") + "/schedule?subgroup=0",
+			statusCode: http.StatusBadRequest, errCode: "bad_request"},
+		{name: "room without storage", path: "/rooms/6-401/schedule", statusCode: http.StatusNotFound,
+			errCode: "not_found"},
+		{name: "unknown action", path: "/groups/" + url.PathEscape("АТсд-21") + "/month",
+			statusCode: http.StatusNotFound, errCode: "not_found"},
+		{name: "unknown resource", path: "/faculties", statusCode: http.StatusNotFound, errCode: "not_found"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rec := doTestRequest(s, testCase.path)
+			assert.EqualValues(t, testCase.statusCode, rec.Code)
+
+			var res errorResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.EqualValues(t, testCase.errCode, res.Error)
+		})
+	}
+
+	t.Run("suggestions", func(t *testing.T) {
+		var res errorResponse
+		assert.NoError(t, json.Unmarshal(doTestRequest(s, "/groups/atsd/schedule").Body.Bytes(), &res))
+		assert.EqualValues(t, []string{"АТсд-21"}, res.Suggestions)
+	})
+	t.Run("method not allowed", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/groups", nil))
+		assert.EqualValues(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
+
+func TestServerStorage(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	fetchedAt := time.Date(2024, 4, 15, 10, 0, 0, 0, time.UTC)
+	assert.NoError(t, store.Save(types.Entity{Type: types.Group, Name: "ИСТбд-11"}, mock.TestGroupSchedule(t),
+		fetchedAt))
+	assert.NoError(t, store.Save(types.Entity{Type: types.Room, Name: "6-401"}, mock.TestRoomSchedule(t), fetchedAt))
+	s, _ := newTestServer(t, store)
+
+	t.Run("site unavailable", func(t *testing.T) {
+		rec := doTestRequest(s, "/groups/"+url.PathEscape("ИСТбд-11")+"/schedule")
+		assert.EqualValues(t, http.StatusOK, rec.Code)
+		assert.EqualValues(t, "true", rec.Header().Get(StaleHeader))
+		assert.EqualValues(t, "2024-04-15T10:00:00Z", rec.Header().Get(FetchedAtHeader))
+	})
+	t.Run("room", func(t *testing.T) {
+		rec := doTestRequest(s, "/rooms/6-401/schedule")
+		assert.EqualValues(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get(StaleHeader))
+	})
+}
+
+func TestServerOpenAPI(t *testing.T) {
+	s, _ := newTestServer(t, nil)
+
+	rec := doTestRequest(s, "/openapi.yaml")
+	assert.EqualValues(t, http.StatusOK, rec.Code)
+	assert.True(t, strings.HasPrefix(rec.Body.String(), "openapi: 3.0.3"))
+}
+
+func TestSplitPath(t *testing.T) {
+	segments, err := splitPath("/teachers/%D0%97%D0%B5%D0%BD%D0%BA%D0%B8%D0%BD%D0%B0%20%D0%A1%20%D0%9C/day/")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"teachers", "Зенкина С М", "day"}, segments)
+
+	segments, err = splitPath("/rooms/6-%2F401/schedule")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"rooms", "6-/401", "schedule"}, segments)
+
+	_, err = splitPath("/groups/%zz/schedule")
+	assert.Error(t, err)
+}
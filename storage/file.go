@@ -0,0 +1,174 @@
+package storage
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mailru/easyjson"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+const scheduleFileExt = ".json"
+
+// errEmptyEntityName is returned when the schedule of the entity with the empty name is saved.
+var errEmptyEntityName = errors.New("entity name is empty")
+
+// scheduleTypeDirs contains the names of the directories with the schedules of each type.
+var scheduleTypeDirs = map[types.ScheduleType]string{types.Group: "groups", types.Teacher: "teachers",
+	types.Room: "rooms"}
+
+// FileStorage stores the schedules as JSON files. Every version of the schedule is stored in the file
+// <dir>/<type>/<escaped name>/<fetch time in unix nanoseconds>.json. The names "." and ".." are escaped as
+// "%2E" and "%2E%2E", so every entity has its own directory.
+type FileStorage struct {
+	dir string
+}
+
+// NewFileStorage returns *FileStorage that stores the schedules in dir. The directory is created if it does not
+// exist.
+func NewFileStorage(dir string) (*FileStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileStorage{dir: dir}, nil
+}
+
+// Save saves the schedule of the entity fetched at fetchedAt. Returns *types.EntityError if the name of the entity is
+// empty.
+func (s *FileStorage) Save(entity types.Entity, schedule *types.Schedule, fetchedAt time.Time) error {
+	if entity.Name == "" {
+		return &types.EntityError{Entity: entity, Err: errEmptyEntityName}
+	}
+
+	entityDir := s.getEntityDir(entity)
+	if err := os.MkdirAll(entityDir, 0o755); err != nil {
+		return err
+	}
+
+	data, err := easyjson.Marshal(schedule)
+	if err != nil {
+		return err
+	}
+
+	// the file is renamed after writing, so the incomplete file is never loaded
+	tmpFile, err := ioutil.TempFile(entityDir, "*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmpFile.Name())
+	}()
+
+	if _, err = tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		return err
+	}
+	if err = tmpFile.Close(); err != nil {
+		return err
+	}
+
+	fileName := strconv.FormatInt(fetchedAt.UnixNano(), 10) + scheduleFileExt
+	return os.Rename(tmpFile.Name(), filepath.Join(entityDir, fileName))
+}
+
+// Load returns the latest schedule of the entity fetched not later than at and its fetch time. If at is zero, the
+// latest schedule is returned.
+func (s *FileStorage) Load(entity types.Entity, at time.Time) (*types.Schedule, time.Time, error) {
+	if entity.Name == "" {
+		return nil, time.Time{}, &types.NotStoredError{Entity: entity, At: at}
+	}
+
+	files, err := ioutil.ReadDir(s.getEntityDir(entity))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, time.Time{}, err
+	}
+
+	var (
+		latestFile string
+		latest     int64
+	)
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), scheduleFileExt) {
+			continue
+		}
+
+		fetchedAt, err := strconv.ParseInt(strings.TrimSuffix(file.Name(), scheduleFileExt), 10, 64)
+		if err != nil || (!at.IsZero() && fetchedAt > at.UnixNano()) {
+			continue
+		}
+		if latestFile == "" || fetchedAt > latest {
+			latestFile, latest = file.Name(), fetchedAt
+		}
+	}
+
+	if latestFile == "" {
+		return nil, time.Time{}, &types.NotStoredError{Entity: entity, At: at}
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(s.getEntityDir(entity), latestFile))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	schedule := &types.Schedule{}
+	if err = easyjson.Unmarshal(data, schedule); err != nil {
+		return nil, time.Time{}, err
+	}
+	return schedule, time.Unix(0, latest), nil
+}
+
+// Entities returns the entities which schedules are stored.
+func (s *FileStorage) Entities() ([]types.Entity, error) {
+	entities := make([]types.Entity, 0)
+
+	for _, scheduleType := range []types.ScheduleType{types.Group, types.Teacher, types.Room} {
+		entityDirs, err := ioutil.ReadDir(filepath.Join(s.dir, scheduleTypeDirs[scheduleType]))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entityDir := range entityDirs {
+			if !entityDir.IsDir() {
+				continue
+			}
+
+			name, err := url.PathUnescape(entityDir.Name())
+			if err != nil {
+				continue
+			}
+			entities = append(entities, types.Entity{Type: scheduleType, Name: name})
+		}
+	}
+
+	sortEntities(entities)
+	return entities, nil
+}
+
+// Close does nothing, the files are closed after each operation.
+func (s *FileStorage) Close() error {
+	return nil
+}
+
+// getEntityDir returns the directory with the schedules of the entity. The name must not be empty.
+func (s *FileStorage) getEntityDir(entity types.Entity) string {
+	return filepath.Join(s.dir, scheduleTypeDirs[entity.Type], escapeEntityName(entity.Name))
+}
+
+// escapeEntityName returns the name of the entity escaped to be the name of its directory. url.PathEscape leaves
+// "." and ".." as is, so their dots are escaped too.
+func escapeEntityName(name string) string {
+	escaped := url.PathEscape(name)
+	if escaped == "." || escaped == ".." {
+		return strings.ReplaceAll(escaped, ".", "%2E")
+	}
+	return escaped
+}
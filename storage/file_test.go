@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+func TestFileStorage(t *testing.T) {
+	s, err := NewFileStorage(t.TempDir())
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	testStorage(t, s)
+}
+
+func TestFileStorageDotNames(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFileStorage(dir)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	fetchedAt := time.Date(2024, 4, 16, 9, 0, 0, 0, time.UTC)
+	dotEntities := []types.Entity{{Type: types.Room, Name: "."}, {Type: types.Room, Name: ".."}}
+	for _, entity := range dotEntities {
+		assert.NoError(t, s.Save(entity, &types.Schedule{}, fetchedAt))
+	}
+
+	// the schedules are not saved to the storage root or to the directory of the type
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	if assert.Len(t, files, 1) {
+		assert.EqualValues(t, "rooms", files[0].Name())
+	}
+	files, err = ioutil.ReadDir(filepath.Join(dir, "rooms"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	entities, err := s.Entities()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, dotEntities, entities)
+
+	_, loadedAt, err := s.Load(types.Entity{Type: types.Room, Name: ".."}, time.Time{})
+	assert.NoError(t, err)
+	assert.True(t, fetchedAt.Equal(loadedAt))
+
+	emptyEntity := types.Entity{Type: types.Room, Name: ""}
+	var entityErr *types.EntityError
+	assert.ErrorAs(t, s.Save(emptyEntity, &types.Schedule{}, fetchedAt), &entityErr)
+	_, _, err = s.Load(emptyEntity, time.Time{})
+	assert.ErrorIs(t, err, types.ErrNotStored)
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ulstu-schedule/parser/types"
+)
+
+// scheduleVersion is the version of the schedule stored in MemoryStorage.
+type scheduleVersion struct {
+	schedule  *types.Schedule
+	fetchedAt time.Time
+}
+
+// MemoryStorage stores the schedules in memory. It is useful for tests and for the processes that do not need to
+// keep the schedules after restart.
+type MemoryStorage struct {
+	mu       sync.RWMutex
+	versions map[types.Entity][]scheduleVersion // sorted by the fetch time
+}
+
+// NewMemoryStorage returns an empty *MemoryStorage.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{versions: map[types.Entity][]scheduleVersion{}}
+}
+
+// Save saves the schedule of the entity fetched at fetchedAt.
+func (s *MemoryStorage) Save(entity types.Entity, schedule *types.Schedule, fetchedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	versions := s.versions[entity]
+	versionIdx := sort.Search(len(versions), func(i int) bool {
+		return !versions[i].fetchedAt.Before(fetchedAt)
+	})
+
+	version := scheduleVersion{schedule: schedule, fetchedAt: fetchedAt}
+	if versionIdx < len(versions) && versions[versionIdx].fetchedAt.Equal(fetchedAt) {
+		versions[versionIdx] = version
+		return nil
+	}
+
+	versions = append(versions, scheduleVersion{})
+	copy(versions[versionIdx+1:], versions[versionIdx:])
+	versions[versionIdx] = version
+	s.versions[entity] = versions
+	return nil
+}
+
+// Load returns the latest schedule of the entity fetched not later than at and its fetch time. If at is zero, the
+// latest schedule is returned.
+func (s *MemoryStorage) Load(entity types.Entity, at time.Time) (*types.Schedule, time.Time, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	versions := s.versions[entity]
+	for versionIdx := len(versions) - 1; versionIdx >= 0; versionIdx-- {
+		if at.IsZero() || !versions[versionIdx].fetchedAt.After(at) {
+			return versions[versionIdx].schedule, versions[versionIdx].fetchedAt, nil
+		}
+	}
+	return nil, time.Time{}, &types.NotStoredError{Entity: entity, At: at}
+}
+
+// Entities returns the entities which schedules are stored.
+func (s *MemoryStorage) Entities() ([]types.Entity, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entities := make([]types.Entity, 0, len(s.versions))
+	for entity := range s.versions {
+		entities = append(entities, entity)
+	}
+
+	sortEntities(entities)
+	return entities, nil
+}
+
+// Close does nothing.
+func (s *MemoryStorage) Close() error {
+	return nil
+}
@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/mailru/easyjson"
+	// registers the "sqlite3" driver
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+const sqliteSchema = `CREATE TABLE IF NOT EXISTS schedules (
+	type       INTEGER NOT NULL,
+	name       TEXT    NOT NULL,
+	fetched_at INTEGER NOT NULL,
+	data       BLOB    NOT NULL,
+	PRIMARY KEY (type, name, fetched_at)
+)`
+
+// SQLiteStorage stores the schedules as JSON in the embedded SQLite database.
+type SQLiteStorage struct {
+	db *sql.DB
+}
+
+// NewSQLiteStorage returns *SQLiteStorage that stores the schedules in the database file at path. The database is
+// created if it does not exist.
+func NewSQLiteStorage(path string) (*SQLiteStorage, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = db.Exec(sqliteSchema); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &SQLiteStorage{db: db}, nil
+}
+
+// Save saves the schedule of the entity fetched at fetchedAt.
+func (s *SQLiteStorage) Save(entity types.Entity, schedule *types.Schedule, fetchedAt time.Time) error {
+	data, err := easyjson.Marshal(schedule)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec("INSERT OR REPLACE INTO schedules (type, name, fetched_at, data) VALUES (?, ?, ?, ?)",
+		int(entity.Type), entity.Name, fetchedAt.UnixNano(), data)
+	return err
+}
+
+// Load returns the latest schedule of the entity fetched not later than at and its fetch time. If at is zero, the
+// latest schedule is returned.
+func (s *SQLiteStorage) Load(entity types.Entity, at time.Time) (*types.Schedule, time.Time, error) {
+	query := "SELECT fetched_at, data FROM schedules WHERE type = ? AND name = ? ORDER BY fetched_at DESC LIMIT 1"
+	args := []interface{}{int(entity.Type), entity.Name}
+	if !at.IsZero() {
+		query = "SELECT fetched_at, data FROM schedules WHERE type = ? AND name = ? AND fetched_at <= ? " +
+			"ORDER BY fetched_at DESC LIMIT 1"
+		args = append(args, at.UnixNano())
+	}
+
+	var (
+		fetchedAt int64
+		data      []byte
+	)
+	err := s.db.QueryRow(query, args...).Scan(&fetchedAt, &data)
+	if err == sql.ErrNoRows {
+		return nil, time.Time{}, &types.NotStoredError{Entity: entity, At: at}
+	}
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	schedule := &types.Schedule{}
+	if err = easyjson.Unmarshal(data, schedule); err != nil {
+		return nil, time.Time{}, err
+	}
+	return schedule, time.Unix(0, fetchedAt), nil
+}
+
+// Entities returns the entities which schedules are stored.
+func (s *SQLiteStorage) Entities() ([]types.Entity, error) {
+	rows, err := s.db.Query("SELECT DISTINCT type, name FROM schedules ORDER BY type, name")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	entities := make([]types.Entity, 0)
+	for rows.Next() {
+		var (
+			scheduleType int
+			name         string
+		)
+		if err = rows.Scan(&scheduleType, &name); err != nil {
+			return nil, err
+		}
+		entities = append(entities, types.Entity{Type: types.ScheduleType(scheduleType), Name: name})
+	}
+	return entities, rows.Err()
+}
+
+// Close closes the database.
+func (s *SQLiteStorage) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLiteStorage(t *testing.T) {
+	s, err := NewSQLiteStorage(filepath.Join(t.TempDir(), "schedules.db"))
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	testStorage(t, s)
+}
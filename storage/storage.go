@@ -0,0 +1,73 @@
+// Package storage persists the schedules of the groups, teachers and rooms, so they can be served when UlSTU site
+// is unavailable.
+package storage
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/ulstu-schedule/parser/types"
+)
+
+// Storage saves and loads the schedules keyed by the entity and the fetch time. Every saved schedule is kept as a
+// separate version.
+type Storage interface {
+	// Save saves the schedule of the entity fetched at fetchedAt.
+	Save(entity types.Entity, schedule *types.Schedule, fetchedAt time.Time) error
+	// Load returns the latest schedule of the entity fetched not later than at and its fetch time. If at is zero,
+	// the latest schedule is returned. Returns *types.NotStoredError if there is no such schedule.
+	Load(entity types.Entity, at time.Time) (*types.Schedule, time.Time, error)
+	// Entities returns the entities which schedules are stored.
+	Entities() ([]types.Entity, error)
+	Close() error
+}
+
+// SaveSnapshot saves all the schedules of the snapshot.
+func SaveSnapshot(s Storage, snapshot *types.Snapshot) error {
+	for entity, schedule := range snapshot.Schedules {
+		if err := s.Save(entity, schedule, snapshot.FetchedAt[entity]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadSnapshot returns the snapshot with the latest schedules of all the stored entities fetched not later than at.
+// If at is zero, the latest schedules are returned. The entities without such schedules are skipped.
+func LoadSnapshot(s Storage, at time.Time) (*types.Snapshot, error) {
+	entities, err := s.Entities()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := types.NewSnapshot()
+	for _, entity := range entities {
+		schedule, fetchedAt, err := s.Load(entity, at)
+		if errors.Is(err, types.ErrNotStored) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot.Add(entity, schedule, fetchedAt)
+		if snapshot.StartedAt.IsZero() || fetchedAt.Before(snapshot.StartedAt) {
+			snapshot.StartedAt = fetchedAt
+		}
+		if fetchedAt.After(snapshot.FinishedAt) {
+			snapshot.FinishedAt = fetchedAt
+		}
+	}
+	return snapshot, nil
+}
+
+// sortEntities sorts the entities by the schedule type and the name.
+func sortEntities(entities []types.Entity) {
+	sort.Slice(entities, func(i, j int) bool {
+		if entities[i].Type != entities[j].Type {
+			return entities[i].Type < entities[j].Type
+		}
+		return entities[i].Name < entities[j].Name
+	})
+}
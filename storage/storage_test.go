@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/mock"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+var (
+	testGroupEntity   = types.Entity{Type: types.Group, Name: "АТсд-21"}
+	testTeacherEntity = types.Entity{Type: types.Teacher, Name: "Зенкина С М"}
+	testFetchedAt     = time.Date(2024, 4, 16, 10, 0, 0, 0, time.UTC)
+)
+
+// testStorage checks that the storage implements the Storage contract.
+func testStorage(t *testing.T, s Storage) {
+	t.Helper()
+
+	groupSchedule := mock.TestGroupSchedule(t)
+	updatedGroupSchedule := mock.TestGroupSchedule(t)
+	updatedGroupSchedule.Weeks[0].Days[0].Lessons[1].SubLessons[0].Room = "6-401"
+
+	assert.NoError(t, s.Save(testGroupEntity, groupSchedule, testFetchedAt))
+	assert.NoError(t, s.Save(testGroupEntity, updatedGroupSchedule, testFetchedAt.Add(time.Hour)))
+	assert.NoError(t, s.Save(testTeacherEntity, mock.TestTeacherSchedule(t), testFetchedAt))
+
+	t.Run("latest", func(t *testing.T) {
+		schedule, fetchedAt, err := s.Load(testGroupEntity, time.Time{})
+		assert.NoError(t, err)
+		assert.True(t, fetchedAt.Equal(testFetchedAt.Add(time.Hour)))
+		assert.EqualValues(t, updatedGroupSchedule, schedule)
+	})
+	t.Run("at time", func(t *testing.T) {
+		schedule, fetchedAt, err := s.Load(testGroupEntity, testFetchedAt.Add(time.Minute))
+		assert.NoError(t, err)
+		assert.True(t, fetchedAt.Equal(testFetchedAt))
+		assert.EqualValues(t, groupSchedule, schedule)
+	})
+	t.Run("not stored", func(t *testing.T) {
+		_, _, err := s.Load(testGroupEntity, testFetchedAt.Add(-time.Minute))
+		assert.True(t, errors.Is(err, types.ErrNotStored))
+
+		_, _, err = s.Load(types.Entity{Type: types.Room, Name: "6-401"}, time.Time{})
+		var notStoredErr *types.NotStoredError
+		assert.True(t, errors.As(err, &notStoredErr))
+	})
+	t.Run("entities", func(t *testing.T) {
+		entities, err := s.Entities()
+		assert.NoError(t, err)
+		assert.EqualValues(t, []types.Entity{testGroupEntity, testTeacherEntity}, entities)
+	})
+}
+
+func TestLoadSnapshot(t *testing.T) {
+	s, err := NewFileStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	snapshot := types.NewSnapshot()
+	snapshot.Add(testGroupEntity, mock.TestGroupSchedule(t), testFetchedAt)
+	snapshot.Add(testTeacherEntity, mock.TestTeacherSchedule(t), testFetchedAt.Add(time.Minute))
+	assert.NoError(t, SaveSnapshot(s, snapshot))
+
+	loaded, err := LoadSnapshot(s, time.Time{})
+	assert.NoError(t, err)
+	assert.Len(t, loaded.Schedules, 2)
+	assert.EqualValues(t, snapshot.Schedules[testGroupEntity], loaded.Schedules[testGroupEntity])
+	assert.True(t, loaded.StartedAt.Equal(testFetchedAt))
+	assert.True(t, loaded.FinishedAt.Equal(testFetchedAt.Add(time.Minute)))
+
+	loaded, err = LoadSnapshot(s, testFetchedAt)
+	assert.NoError(t, err)
+	assert.Len(t, loaded.Schedules, 1)
+	assert.Contains(t, loaded.Schedules, testGroupEntity)
+}
@@ -0,0 +1,218 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DefaultAPIURL is the URL of Telegram Bot API.
+const DefaultAPIURL = "https://api.telegram.org"
+
+// Update is the incoming update of the bot. Only the messages and the presses of the inline buttons are used.
+type Update struct {
+	UpdateID      int64          `json:"update_id"`
+	Message       *Message       `json:"message,omitempty"`
+	CallbackQuery *CallbackQuery `json:"callback_query,omitempty"`
+}
+
+// Message is the message in the chat.
+type Message struct {
+	MessageID int64  `json:"message_id"`
+	Chat      Chat   `json:"chat"`
+	Text      string `json:"text,omitempty"`
+}
+
+// Chat is the chat with the user or the group chat.
+type Chat struct {
+	ID int64 `json:"id"`
+}
+
+// CallbackQuery is the press of the inline button.
+type CallbackQuery struct {
+	ID      string   `json:"id"`
+	Message *Message `json:"message,omitempty"` // message with the button, nil if the message is too old
+	Data    string   `json:"data,omitempty"`
+}
+
+// InlineKeyboardMarkup is the inline keyboard attached to the message.
+type InlineKeyboardMarkup struct {
+	InlineKeyboard [][]InlineKeyboardButton `json:"inline_keyboard"`
+}
+
+// InlineKeyboardButton is the button of the inline keyboard.
+type InlineKeyboardButton struct {
+	Text         string `json:"text"`
+	CallbackData string `json:"callback_data"`
+}
+
+// APIError is returned when Bot API rejects the request.
+type APIError struct {
+	Method      string
+	Code        int
+	Description string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("telegram %s: %d %s", e.Method, e.Code, e.Description)
+}
+
+// apiResponse is the response of Bot API.
+type apiResponse struct {
+	OK          bool            `json:"ok"`
+	Result      json.RawMessage `json:"result"`
+	ErrorCode   int             `json:"error_code"`
+	Description string          `json:"description"`
+}
+
+// Client makes the requests to Telegram Bot API.
+type Client struct {
+	// APIURL is the URL of Bot API, DefaultAPIURL by default
+	APIURL string
+	// HTTPClient is used to make the requests, http.DefaultClient if nil. Its timeout must be longer than the timeout
+	// of the long polling
+	HTTPClient *http.Client
+
+	token string
+}
+
+// NewClient returns *Client of the bot with the token.
+func NewClient(token string) *Client {
+	return &Client{APIURL: DefaultAPIURL, token: token}
+}
+
+// GetUpdates returns the updates starting from offset. The request waits for the updates up to timeout.
+func (c *Client) GetUpdates(ctx context.Context, offset int64, timeout time.Duration) ([]Update, error) {
+	params := map[string]interface{}{
+		"offset":          offset,
+		"timeout":         int(timeout / time.Second),
+		"allowed_updates": []string{"message", "callback_query"},
+	}
+
+	var updates []Update
+	return updates, c.call(ctx, "getUpdates", params, &updates)
+}
+
+// SendMessage sends the text message with the keyboard to the chat. The keyboard may be nil.
+func (c *Client) SendMessage(ctx context.Context, chatID int64, text string, keyboard *InlineKeyboardMarkup) (
+	*Message, error) {
+	params := map[string]interface{}{"chat_id": chatID, "text": text}
+	if keyboard != nil {
+		params["reply_markup"] = keyboard
+	}
+
+	message := &Message{}
+	return message, c.call(ctx, "sendMessage", params, message)
+}
+
+// EditMessageText replaces the text and the keyboard of the message. The keyboard may be nil.
+func (c *Client) EditMessageText(ctx context.Context, chatID, messageID int64, text string,
+	keyboard *InlineKeyboardMarkup) error {
+	params := map[string]interface{}{"chat_id": chatID, "message_id": messageID, "text": text}
+	if keyboard != nil {
+		params["reply_markup"] = keyboard
+	}
+	return c.call(ctx, "editMessageText", params, nil)
+}
+
+// SendPhoto uploads the PNG image and sends it with the caption and the keyboard to the chat. The keyboard may be
+// nil.
+func (c *Client) SendPhoto(ctx context.Context, chatID int64, photo []byte, caption string,
+	keyboard *InlineKeyboardMarkup) (*Message, error) {
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+
+	_ = mw.WriteField("chat_id", strconv.FormatInt(chatID, 10))
+	if caption != "" {
+		_ = mw.WriteField("caption", caption)
+	}
+	if keyboard != nil {
+		keyboardJSON, err := json.Marshal(keyboard)
+		if err != nil {
+			return nil, err
+		}
+		_ = mw.WriteField("reply_markup", string(keyboardJSON))
+	}
+
+	photoWriter, err := mw.CreateFormFile("photo", "schedule.png")
+	if err != nil {
+		return nil, err
+	}
+	if _, err = photoWriter.Write(photo); err != nil {
+		return nil, err
+	}
+	if err = mw.Close(); err != nil {
+		return nil, err
+	}
+
+	message := &Message{}
+	return message, c.do(ctx, "sendPhoto", mw.FormDataContentType(), body, message)
+}
+
+// AnswerCallbackQuery notifies the messenger that the press of the button is handled.
+func (c *Client) AnswerCallbackQuery(ctx context.Context, callbackQueryID string) error {
+	return c.call(ctx, "answerCallbackQuery", map[string]interface{}{"callback_query_id": callbackQueryID}, nil)
+}
+
+// call makes the request to the method with the JSON params and decodes its result into result if it is not nil.
+func (c *Client) call(ctx context.Context, method string, params interface{}, result interface{}) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, method, "application/json", bytes.NewReader(body), result)
+}
+
+// do makes the request to the method and decodes its result into result if it is not nil.
+func (c *Client) do(ctx context.Context, method string, contentType string, body io.Reader,
+	result interface{}) error {
+	apiURL := c.APIURL
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL+"/bot"+c.token+"/"+method, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		// the URL of the request contains the token, so it is removed from the error
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("telegram %s: %w", method, err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	apiRes := &apiResponse{}
+	if err = json.NewDecoder(res.Body).Decode(apiRes); err != nil {
+		return fmt.Errorf("telegram %s: %d %s: %w", method, res.StatusCode, http.StatusText(res.StatusCode), err)
+	}
+	if !apiRes.OK {
+		return &APIError{Method: method, Code: apiRes.ErrorCode, Description: apiRes.Description}
+	}
+
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(apiRes.Result, result)
+}
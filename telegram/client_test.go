@@ -0,0 +1,164 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testToken = "123:secret"
+
+// apiCall is the request received by the fake Bot API.
+type apiCall struct {
+	Method string
+	Params map[string]interface{}
+	Photo  []byte
+}
+
+// fakeAPI is Bot API that records the requests and responds with the results set by the tests.
+type fakeAPI struct {
+	mu      sync.Mutex
+	calls   []apiCall
+	results map[string]string // method -> JSON response
+}
+
+func newFakeAPI(t *testing.T) (*fakeAPI, *Client) {
+	t.Helper()
+
+	api := &fakeAPI{results: map[string]string{}}
+	ts := httptest.NewServer(api)
+	t.Cleanup(ts.Close)
+
+	client := NewClient(testToken)
+	client.APIURL = ts.URL
+	return api, client
+}
+
+func (a *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	prefix := "/bot" + testToken + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"ok":false,"error_code":404,"description":"Not Found"}`))
+		return
+	}
+
+	call := apiCall{Method: strings.TrimPrefix(r.URL.Path, prefix), Params: map[string]interface{}{}}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for key, values := range r.MultipartForm.Value {
+			call.Params[key] = values[0]
+		}
+		if file, _, err := r.FormFile("photo"); err == nil {
+			call.Photo, _ = ioutil.ReadAll(file)
+			_ = file.Close()
+		}
+	} else if err := json.NewDecoder(r.Body).Decode(&call.Params); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	a.mu.Lock()
+	a.calls = append(a.calls, call)
+	result, ok := a.results[call.Method]
+	a.mu.Unlock()
+
+	if !ok {
+		result = `{"ok":true,"result":{"message_id":1,"chat":{"id":1}}}`
+	}
+	_, _ = w.Write([]byte(result))
+}
+
+func (a *fakeAPI) setResult(method, result string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.results[method] = result
+}
+
+func (a *fakeAPI) getCalls() []apiCall {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]apiCall(nil), a.calls...)
+}
+
+func TestClientSendMessage(t *testing.T) {
+	api, client := newFakeAPI(t)
+	api.setResult("sendMessage", `{"ok":true,"result":{"message_id":42,"chat":{"id":7},"text":"hi"}}`)
+
+	keyboard := &InlineKeyboardMarkup{InlineKeyboard: [][]InlineKeyboardButton{{{Text: "▶", CallbackData: "day:1:0:АТсд-21"}}}}
+	message, err := client.SendMessage(context.Background(), 7, "hi", keyboard)
+	assert.Nil(t, err)
+	assert.EqualValues(t, &Message{MessageID: 42, Chat: Chat{ID: 7}, Text: "hi"}, message)
+
+	calls := api.getCalls()
+	if assert.Len(t, calls, 1) {
+		assert.EqualValues(t, "sendMessage", calls[0].Method)
+		assert.EqualValues(t, 7, calls[0].Params["chat_id"])
+		assert.EqualValues(t, "hi", calls[0].Params["text"])
+		assert.EqualValues(t, map[string]interface{}{
+			"inline_keyboard": []interface{}{[]interface{}{
+				map[string]interface{}{"text": "▶", "callback_data": "day:1:0:АТсд-21"},
+			}},
+		}, calls[0].Params["reply_markup"])
+	}
+}
+
+func TestClientSendPhoto(t *testing.T) {
+	api, client := newFakeAPI(t)
+
+	_, err := client.SendPhoto(context.Background(), 7, []byte("png"), "caption", nil)
+	assert.Nil(t, err)
+
+	calls := api.getCalls()
+	if assert.Len(t, calls, 1) {
+		assert.EqualValues(t, "sendPhoto", calls[0].Method)
+		assert.EqualValues(t, map[string]interface{}{"chat_id": "7", "caption": "caption"}, calls[0].Params)
+		assert.EqualValues(t, []byte("png"), calls[0].Photo)
+	}
+}
+
+func TestClientGetUpdates(t *testing.T) {
+	api, client := newFakeAPI(t)
+	api.setResult("getUpdates", `{"ok":true,"result":[{"update_id":5,"message":{"message_id":1,"chat":{"id":7},`+
+		`"text":"/today"}}]}`)
+
+	updates, err := client.GetUpdates(context.Background(), 5, 30*time.Second)
+	assert.Nil(t, err)
+	assert.EqualValues(t, []Update{{UpdateID: 5, Message: &Message{MessageID: 1, Chat: Chat{ID: 7}, Text: "/today"}}},
+		updates)
+
+	calls := api.getCalls()
+	if assert.Len(t, calls, 1) {
+		assert.EqualValues(t, 5, calls[0].Params["offset"])
+		assert.EqualValues(t, 30, calls[0].Params["timeout"])
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	api, client := newFakeAPI(t)
+	api.setResult("sendMessage", `{"ok":false,"error_code":403,"description":"Forbidden: bot was blocked by the user"}`)
+
+	_, err := client.SendMessage(context.Background(), 7, "hi", nil)
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.EqualValues(t, &APIError{Method: "sendMessage", Code: 403,
+			Description: "Forbidden: bot was blocked by the user"}, apiErr)
+	}
+
+	// the token is not revealed by the network errors
+	client.APIURL = "http://127.0.0.1:0"
+	_, err = client.SendMessage(context.Background(), 7, "hi", nil)
+	assert.NotNil(t, err)
+	assert.NotContains(t, err.Error(), testToken)
+}
@@ -0,0 +1,165 @@
+// Package telegram connects the schedule bot of the bot package to Telegram Bot API. The updates are received by
+// the long polling or by the webhook.
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ulstu-schedule/parser/bot"
+)
+
+const (
+	defaultPollTimeout = 30 * time.Second
+	// pollRetryDelay is the delay before the next request of the updates after the failed one
+	pollRetryDelay = 5 * time.Second
+	// secretTokenHeader contains the secret token set by setWebhook in the requests to the webhook
+	secretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+)
+
+// Bot receives the updates from Telegram, passes them to the handler and sends its responses.
+type Bot struct {
+	// PollTimeout is the time the request of the updates waits for them, 30 seconds by default
+	PollTimeout time.Duration
+	// SecretToken is the secret token set by setWebhook. The requests to the webhook with another token are rejected
+	// if it is not empty
+	SecretToken string
+	// OnError is called when the updates cannot be received or the response cannot be sent
+	OnError func(err error)
+
+	client  *Client
+	handler *bot.Handler
+}
+
+// NewBot returns *Bot that handles the updates received by client with handler.
+func NewBot(client *Client, handler *bot.Handler) *Bot {
+	return &Bot{PollTimeout: defaultPollTimeout, client: client, handler: handler}
+}
+
+// Run receives the updates by the long polling and handles them one by one until ctx is done. Returns ctx.Err().
+func (b *Bot) Run(ctx context.Context) error {
+	var offset int64
+	for {
+		updates, err := b.client.GetUpdates(ctx, offset, b.PollTimeout)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			b.reportError(err)
+
+			timer := time.NewTimer(pollRetryDelay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+			continue
+		}
+
+		for _, update := range updates {
+			offset = update.UpdateID + 1
+			if err = b.HandleUpdate(ctx, update); err != nil && ctx.Err() == nil {
+				b.reportError(err)
+			}
+		}
+	}
+}
+
+// ServeHTTP handles the update sent to the webhook of the bot. The update is rejected if SecretToken is set and the
+// request has another secret token.
+func (b *Bot) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if b.SecretToken != "" && r.Header.Get(secretTokenHeader) != b.SecretToken {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	update := Update{}
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Telegram resends the update if the response is not successful, so the errors are only reported
+	if err := b.HandleUpdate(r.Context(), update); err != nil {
+		b.reportError(err)
+	}
+}
+
+// HandleUpdate handles the message or the press of the inline button. The text response to the press replaces the
+// message with the button.
+func (b *Bot) HandleUpdate(ctx context.Context, update Update) error {
+	switch {
+	case update.Message != nil && update.Message.Text != "":
+		res := b.handler.HandleMessage(update.Message.Chat.ID, update.Message.Text)
+		return b.send(ctx, update.Message.Chat.ID, res)
+	case update.CallbackQuery != nil:
+		query := update.CallbackQuery
+		if err := b.client.AnswerCallbackQuery(ctx, query.ID); err != nil {
+			return err
+		}
+		if query.Message == nil {
+			return nil
+		}
+
+		res := b.handler.HandleCallback(query.Data)
+		if res.Photo != nil {
+			return b.send(ctx, query.Message.Chat.ID, res)
+		}
+
+		err := b.client.EditMessageText(ctx, query.Message.Chat.ID, query.Message.MessageID, res.Text,
+			convertKeyboard(res.Keyboard))
+		// the message is not modified if the same button is pressed twice
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && strings.Contains(apiErr.Description, "message is not modified") {
+			return nil
+		}
+		return err
+	default:
+		return nil
+	}
+}
+
+// send sends the response to the chat as the text message or the photo.
+func (b *Bot) send(ctx context.Context, chatID int64, res bot.Response) error {
+	if res.Photo != nil {
+		_, err := b.client.SendPhoto(ctx, chatID, res.Photo, res.Text, convertKeyboard(res.Keyboard))
+		return err
+	}
+
+	_, err := b.client.SendMessage(ctx, chatID, res.Text, convertKeyboard(res.Keyboard))
+	return err
+}
+
+// reportError passes the error to OnError if it is set.
+func (b *Bot) reportError(err error) {
+	if b.OnError != nil {
+		b.OnError(err)
+	}
+}
+
+// convertKeyboard converts the keyboard of the response to the inline keyboard of Telegram. Returns nil if the
+// keyboard is empty.
+func convertKeyboard(keyboard bot.Keyboard) *InlineKeyboardMarkup {
+	if len(keyboard) == 0 {
+		return nil
+	}
+
+	markup := &InlineKeyboardMarkup{InlineKeyboard: make([][]InlineKeyboardButton, 0, len(keyboard))}
+	for _, row := range keyboard {
+		buttons := make([]InlineKeyboardButton, 0, len(row))
+		for _, button := range row {
+			buttons = append(buttons, InlineKeyboardButton{Text: button.Text, CallbackData: button.Data})
+		}
+		markup.InlineKeyboard = append(markup.InlineKeyboard, buttons)
+	}
+	return markup
+}
@@ -0,0 +1,162 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/bot"
+	"github.com/ulstu-schedule/parser/mock"
+	"github.com/ulstu-schedule/parser/storage"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+func newTestBot(t *testing.T) (*fakeAPI, *Bot) {
+	t.Helper()
+
+	store := storage.NewMemoryStorage()
+	err := store.Save(types.Entity{Type: types.Room, Name: "6-401"}, mock.TestRoomSchedule(t), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	api, client := newFakeAPI(t)
+	return api, NewBot(client, bot.NewHandler(bot.NewMemoryBindings(), store))
+}
+
+func TestBotHandleMessage(t *testing.T) {
+	api, b := newTestBot(t)
+
+	err := b.HandleUpdate(context.Background(), Update{UpdateID: 1, Message: &Message{Chat: Chat{ID: 7}, Text: "/start"}})
+	assert.Nil(t, err)
+
+	err = b.HandleUpdate(context.Background(), Update{UpdateID: 2, Message: &Message{Chat: Chat{ID: 7},
+		Text: "/room 6-401"}})
+	assert.Nil(t, err)
+
+	calls := api.getCalls()
+	if assert.Len(t, calls, 2) {
+		assert.EqualValues(t, "sendMessage", calls[0].Method)
+		assert.True(t, strings.HasPrefix(calls[0].Params["text"].(string), "Бот показывает расписание УлГТУ"))
+		assert.Nil(t, calls[0].Params["reply_markup"])
+
+		assert.EqualValues(t, "sendMessage", calls[1].Method)
+		assert.EqualValues(t, 7, calls[1].Params["chat_id"])
+		assert.NotNil(t, calls[1].Params["reply_markup"])
+	}
+}
+
+func TestBotHandleCallback(t *testing.T) {
+	api, b := newTestBot(t)
+	api.setResult("editMessageText", `{"ok":false,"error_code":400,"description":"Bad Request: message is not `+
+		`modified"}`)
+	message := &Message{MessageID: 3, Chat: Chat{ID: 7}}
+
+	// the same button pressed twice does not fail
+	err := b.HandleUpdate(context.Background(), Update{CallbackQuery: &CallbackQuery{ID: "q1", Message: message,
+		Data: "day:0:2:6-401"}})
+	assert.Nil(t, err)
+
+	err = b.HandleUpdate(context.Background(), Update{CallbackQuery: &CallbackQuery{ID: "q2", Message: message,
+		Data: "week:0:2:6-401"}})
+	assert.Nil(t, err)
+
+	calls := api.getCalls()
+	if assert.Len(t, calls, 4) {
+		assert.EqualValues(t, "answerCallbackQuery", calls[0].Method)
+		assert.EqualValues(t, "q1", calls[0].Params["callback_query_id"])
+		assert.EqualValues(t, "editMessageText", calls[1].Method)
+		assert.EqualValues(t, 3, calls[1].Params["message_id"])
+
+		assert.EqualValues(t, "answerCallbackQuery", calls[2].Method)
+		assert.EqualValues(t, "sendPhoto", calls[3].Method)
+		assert.EqualValues(t, "Расписание 6-401 на текущую неделю", calls[3].Params["caption"])
+		assert.True(t, bytes.HasPrefix(calls[3].Photo, []byte("\x89PNG")))
+	}
+}
+
+func TestBotRun(t *testing.T) {
+	api, b := newTestBot(t)
+	api.setResult("getUpdates", `{"ok":true,"result":[{"update_id":10,"message":{"message_id":1,"chat":{"id":7},`+
+		`"text":"/help"}}]}`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b.OnError = func(err error) {
+		t.Error(err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- b.Run(ctx)
+	}()
+
+	// waits for the second request of the updates, which must continue after the received one
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		var getUpdatesNum int
+		for _, call := range api.getCalls() {
+			if call.Method == "getUpdates" {
+				getUpdatesNum++
+				if getUpdatesNum == 2 {
+					assert.EqualValues(t, 11, call.Params["offset"])
+				}
+			}
+		}
+		if getUpdatesNum >= 2 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestBotServeHTTP(t *testing.T) {
+	api, b := newTestBot(t)
+
+	w := httptest.NewRecorder()
+	b.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/webhook",
+		strings.NewReader(`{"update_id":1,"message":{"message_id":1,"chat":{"id":7},"text":"/help"}}`)))
+	assert.EqualValues(t, http.StatusOK, w.Code)
+	assert.Len(t, api.getCalls(), 1)
+
+	w = httptest.NewRecorder()
+	b.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader("{")))
+	assert.EqualValues(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	b.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/webhook", nil))
+	assert.EqualValues(t, http.StatusMethodNotAllowed, w.Code)
+
+	t.Run("secret token", func(t *testing.T) {
+		api, b := newTestBot(t)
+		b.SecretToken = "secret"
+
+		newRequest := func(secretToken string) *http.Request {
+			r := httptest.NewRequest(http.MethodPost, "/webhook",
+				strings.NewReader(`{"update_id":1,"message":{"message_id":1,"chat":{"id":7},"text":"/help"}}`))
+			if secretToken != "" {
+				r.Header.Set("X-Telegram-Bot-Api-Secret-Token", secretToken)
+			}
+			return r
+		}
+
+		for _, secretToken := range []string{"", "forged"} {
+			w := httptest.NewRecorder()
+			b.ServeHTTP(w, newRequest(secretToken))
+			assert.EqualValues(t, http.StatusForbidden, w.Code)
+		}
+		assert.Empty(t, api.getCalls())
+
+		w := httptest.NewRecorder()
+		b.ServeHTTP(w, newRequest("secret"))
+		assert.EqualValues(t, http.StatusOK, w.Code)
+		assert.Len(t, api.getCalls(), 1)
+	})
+}
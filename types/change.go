@@ -0,0 +1,53 @@
+package types
+
+import "time"
+
+// ChangeKind is the kind of the change of the SubLesson between two versions of the schedule.
+type ChangeKind int
+
+const (
+	// Added means that the SubLesson appeared in the new schedule.
+	Added ChangeKind = iota
+	// Removed means that the SubLesson is missing in the new schedule.
+	Removed
+	// Moved means that the SubLesson was moved to another day or time slot.
+	Moved
+	// RoomChanged means that the SubLesson takes place in another room.
+	RoomChanged
+	// TeacherChanged means that the SubLesson is taught by another teacher.
+	TeacherChanged
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Moved:
+		return "moved"
+	case RoomChanged:
+		return "room changed"
+	case TeacherChanged:
+		return "teacher changed"
+	default:
+		return "unknown change"
+	}
+}
+
+// Change represents the change of the SubLesson between two versions of the schedule. The position of the change is
+// the position of the SubLesson in the new schedule, or in the old one if the SubLesson was removed.
+type Change struct {
+	Kind       ChangeKind
+	WeekNumber int
+	WeekDayNum int       // index of the day in Week.Days
+	LessonNum  int       // index of the lesson in Day.Lessons
+	Date       time.Time // date of the day, zero if the week dates are unknown
+	// PrevWeekDayNum, PrevLessonNum and PrevDate are the position of the SubLesson in the old schedule, set if Kind
+	// is Moved
+	PrevWeekDayNum int
+	PrevLessonNum  int
+	PrevDate       time.Time
+	Before         *SubLesson // nil if Kind is Added
+	After          *SubLesson // nil if Kind is Removed
+}
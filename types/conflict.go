@@ -0,0 +1,39 @@
+package types
+
+import "time"
+
+// ConflictKind is the kind of the conflict in the published schedules.
+type ConflictKind int
+
+const (
+	// TeacherConflict means that the teacher has lessons in different rooms at the same time.
+	TeacherConflict ConflictKind = iota
+	// RoomConflict means that the room is booked by unrelated lessons at the same time.
+	RoomConflict
+	// GroupConflict means that the group or its subgroup has different lessons at the same time.
+	GroupConflict
+)
+
+func (k ConflictKind) String() string {
+	switch k {
+	case TeacherConflict:
+		return "teacher conflict"
+	case RoomConflict:
+		return "room conflict"
+	case GroupConflict:
+		return "group conflict"
+	default:
+		return "unknown conflict"
+	}
+}
+
+// Conflict represents the SubLessons that cannot take place at the same time.
+type Conflict struct {
+	Kind       ConflictKind
+	Entity     Entity // teacher, room or group which has the conflicting SubLessons
+	WeekNumber int
+	WeekDayNum int       // index of the day in Week.Days
+	LessonNum  int       // index of the lesson in Day.Lessons
+	Date       time.Time // date of the day, zero if the week dates are unknown
+	SubLessons []SubLesson
+}
@@ -0,0 +1,45 @@
+package types
+
+import "fmt"
+
+// ParseWarningKind is the kind of the problem found in the schedule table cell while parsing.
+type ParseWarningKind int
+
+const (
+	// UnparsedFragment means that the part of the cell was not recognized and was dropped.
+	UnparsedFragment ParseWarningKind = iota
+	// UnknownLessonTypeFragment means that the lesson type was not recognized and Unknown was used.
+	UnknownLessonTypeFragment
+	// IncompleteLessonInfo means that the cell does not contain all the parts of the lesson info.
+	IncompleteLessonInfo
+)
+
+func (k ParseWarningKind) String() string {
+	switch k {
+	case UnparsedFragment:
+		return "unparsed fragment"
+	case UnknownLessonTypeFragment:
+		return "unknown lesson type"
+	case IncompleteLessonInfo:
+		return "incomplete lesson info"
+	default:
+		return "unknown warning"
+	}
+}
+
+// ParseWarning represents the problem found in the schedule table cell while parsing. The lesson may be missing or
+// parsed incorrectly, so the warning contains the coordinates and the raw HTML of the cell.
+type ParseWarning struct {
+	Kind       ParseWarningKind
+	WeekNumber int    // the school week number from the table heading
+	WeekIdx    int    // index of the week in Schedule.Weeks
+	WeekDayNum int    // index of the day in Week.Days
+	LessonNum  int    // index of the lesson in Day.Lessons
+	Fragment   string // the part of the cell that caused the warning
+	CellHTML   string
+}
+
+func (w ParseWarning) String() string {
+	return fmt.Sprintf("%s: %q (week number: %d, weekday number: %d, lesson number: %d)", w.Kind, w.Fragment,
+		w.WeekNumber, w.WeekDayNum, w.LessonNum)
+}
@@ -1,12 +1,48 @@
 package types
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 )
 
+// Sentinel errors that the errors returned by the parser can be checked against with errors.Is.
+var (
+	// ErrNotFound means that the group or teacher does not exist on the UlSTU site.
+	ErrNotFound = errors.New("not found")
+	// ErrNotPublished means that the schedule is missing or not published yet.
+	ErrNotPublished = errors.New("schedule is not published")
+	// ErrSiteUnavailable means that the UlSTU site cannot be reached or responds with an error.
+	ErrSiteUnavailable = errors.New("schedule site is unavailable")
+	// ErrLayoutChanged means that the page of the UlSTU site cannot be parsed.
+	ErrLayoutChanged = errors.New("schedule layout has changed")
+	// ErrNotStored means that the storage does not contain the schedule.
+	ErrNotStored = errors.New("schedule is not stored")
+)
+
+// RequestError is returned when the request to the UlSTU site fails. It wraps the underlying network error.
+type RequestError struct {
+	URL string
+	Err error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("request to %s failed: %s", e.URL, e.Err)
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+func (e *RequestError) Is(target error) bool {
+	return target == ErrSiteUnavailable
+}
+
 // StatusCodeError is returned when a http.Get returns a response with a status code other than 200.
 type StatusCodeError struct {
+	URL        string
 	StatusCode int
 	StatusText string
 }
@@ -15,6 +51,10 @@ func (e *StatusCodeError) Error() string {
 	return fmt.Sprintf("status code error: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
 }
 
+func (e *StatusCodeError) Is(target error) bool {
+	return target == ErrSiteUnavailable
+}
+
 // IncorrectDateError is returned when the date does not match the "dd.mm" format or does not exist.
 type IncorrectDateError struct {
 	Date string
@@ -24,6 +64,15 @@ func (e *IncorrectDateError) Error() string {
 	return fmt.Sprintf("incorrect date: %s", e.Date)
 }
 
+// IncorrectSubGroupError is returned when the number of the subgroup is not positive.
+type IncorrectSubGroupError struct {
+	SubGroup string
+}
+
+func (e *IncorrectSubGroupError) Error() string {
+	return fmt.Sprintf("incorrect subgroup: %s", e.SubGroup)
+}
+
 // IncorrectWeekNumberError is returned when the value of the school week number is out of the acceptable range.
 type IncorrectWeekNumberError struct {
 	WeekNum int
@@ -33,16 +82,56 @@ func (e *IncorrectWeekNumberError) Error() string {
 	return fmt.Sprintf("incorrect value of the school week number: %d", e.WeekNum)
 }
 
-// UnavailableScheduleError is returned when the week schedule is missing or not published.
+// NotFoundError is returned when the group or teacher does not exist on the UlSTU site.
+type NotFoundError struct {
+	Name        string
+	Type        ScheduleType
+	Suggestions []string // the nearest existing names
+}
+
+func (e *NotFoundError) Error() string {
+	if len(e.Suggestions) > 0 {
+		return fmt.Sprintf("%s is not found, did you mean: %s", e.Name, strings.Join(e.Suggestions, ", "))
+	}
+	return fmt.Sprintf("%s is not found", e.Name)
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// ScheduleScope is the part of the schedule that was requested.
+type ScheduleScope int
+
+const (
+	FullScope ScheduleScope = iota
+	WeekScope
+	DayScope
+)
+
+// UnavailableScheduleError is returned when the schedule is missing or not published.
 type UnavailableScheduleError struct {
 	Name       string // teacher or group name
-	WeekNum    int    // equals -1 if the full schedule is requested
-	WeekDayNum int    // equals -1 if the week schedule is requested
+	Scope      ScheduleScope
+	WeekNum    int // index of the week in Schedule.Weeks, set if Scope is WeekScope or DayScope
+	WeekDayNum int // index of the day in Week.Days, set if Scope is DayScope
 }
 
 func (e *UnavailableScheduleError) Error() string {
-	return fmt.Sprintf("the schedule is missing or not published: the schedule of %s, week number: %d, weekday number: %d",
-		e.Name, e.WeekNum, e.WeekDayNum)
+	switch e.Scope {
+	case WeekScope:
+		return fmt.Sprintf("the schedule is missing or not published: the schedule of %s, week number: %d",
+			e.Name, e.WeekNum)
+	case DayScope:
+		return fmt.Sprintf("the schedule is missing or not published: the schedule of %s, week number: %d, weekday number: %d",
+			e.Name, e.WeekNum, e.WeekDayNum)
+	default:
+		return fmt.Sprintf("the schedule is missing or not published: the schedule of %s", e.Name)
+	}
+}
+
+func (e *UnavailableScheduleError) Is(target error) bool {
+	return target == ErrNotPublished
 }
 
 // IncorrectLinkError is returned when when the schedule on the link does not match the expected schedule.
@@ -54,3 +143,79 @@ type IncorrectLinkError struct {
 func (e *IncorrectLinkError) Error() string {
 	return fmt.Sprintf("mismatch between schedule objects: %s != %s", e.Name, e.NameFromURL)
 }
+
+// IncorrectTimeSlotError is returned when the time slot does not match the "hh:mm-hh:mm" format.
+type IncorrectTimeSlotError struct {
+	Slot string
+}
+
+func (e *IncorrectTimeSlotError) Error() string {
+	return fmt.Sprintf("incorrect time slot: %s", e.Slot)
+}
+
+// LayoutError is returned when the layout of the schedule page is not recognized.
+type LayoutError struct {
+	Table  int // index of the table on the page, equals -1 if the error concerns the whole page
+	Row    int // index of the row in the table, equals -1 if the error concerns the whole table
+	Reason string
+}
+
+func (e *LayoutError) Error() string {
+	return fmt.Sprintf("unrecognized schedule layout: %s (table: %d, row: %d)", e.Reason, e.Table, e.Row)
+}
+
+func (e *LayoutError) Is(target error) bool {
+	return target == ErrLayoutChanged
+}
+
+// ParseError is returned when the page of the UlSTU site cannot be parsed: the layout is not recognized or the parser
+// failed on unexpected HTML.
+type ParseError struct {
+	URL      string
+	Entity   string // teacher or group name, empty if the page is a list of groups or teachers
+	Location string // part of the page that was being parsed
+	Cause    error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("failed to parse %s of %q (%s): %s", e.Location, e.Entity, e.URL, e.Cause)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Cause
+}
+
+func (e *ParseError) Is(target error) bool {
+	return target == ErrLayoutChanged
+}
+
+// EntityError is returned when the schedule of the group or teacher cannot be fetched while others can.
+type EntityError struct {
+	Entity Entity
+	Err    error
+}
+
+func (e *EntityError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Entity, e.Err)
+}
+
+func (e *EntityError) Unwrap() error {
+	return e.Err
+}
+
+// NotStoredError is returned when the storage does not contain the schedule of the entity fetched not later than At.
+type NotStoredError struct {
+	Entity Entity
+	At     time.Time // zero if the latest schedule was requested
+}
+
+func (e *NotStoredError) Error() string {
+	if e.At.IsZero() {
+		return fmt.Sprintf("the schedule of %s is not stored", e.Entity)
+	}
+	return fmt.Sprintf("the schedule of %s fetched before %s is not stored", e.Entity, e.At.Format(time.RFC3339))
+}
+
+func (e *NotStoredError) Is(target error) bool {
+	return target == ErrNotStored
+}
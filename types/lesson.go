@@ -0,0 +1,26 @@
+package types
+
+import "time"
+
+// DatedLesson is the Lesson of the schedule placed on the certain date, e.g. the current or the next lesson.
+type DatedLesson struct {
+	WeekNum    int // index of the week in Schedule.Weeks
+	WeekDayNum int // index of the day in Week.Days
+	Duration   Duration
+	Start      time.Time
+	End        time.Time
+	SubLessons []SubLesson
+	// MinutesUntilStart is the number of minutes left before the start of the lesson rounded up, 0 if the lesson has
+	// already started
+	MinutesUntilStart int
+}
+
+// FreeSlot is the time slot of the day in which all the participants have no lessons.
+type FreeSlot struct {
+	Date     time.Time // beginning of the day
+	Duration Duration
+	// Gaps is the total number of the idle time slots the participants get between their lessons and this slot
+	Gaps int
+	// FreeDayNum is the number of the participants that have no lessons on the day
+	FreeDayNum int
+}
@@ -0,0 +1,29 @@
+package types
+
+// DayLoad is the load of the day of the schedule: how the lessons are spread over the day.
+type DayLoad struct {
+	// FirstLesson and LastLesson are the indexes of the first and the last lessons in Day.Lessons, -1 if the day has
+	// no lessons
+	FirstLesson int
+	LastLesson  int
+	LessonsNum  int
+	// GapSlots is the number of the idle time slots ("окна") between the first and the last lessons
+	GapSlots int
+	// GapMinutes is the time in minutes from the end of the lesson to the start of the next one, summed over the
+	// lessons separated by the idle time slots
+	GapMinutes int
+	// BuildingChanges is the number of the consecutive lessons that take place in different buildings, the lessons
+	// with the unknown building are skipped
+	BuildingChanges int
+}
+
+// WeekLoad is the load of the week of the schedule with the totals over its days.
+type WeekLoad struct {
+	WeekNumber      int
+	Days            [7]DayLoad
+	StudyDaysNum    int // number of the days with lessons
+	LessonsNum      int
+	GapSlots        int
+	GapMinutes      int
+	BuildingChanges int
+}
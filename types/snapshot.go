@@ -0,0 +1,38 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// Entity represents the group, teacher or room which the schedule belongs to.
+type Entity struct {
+	Type ScheduleType
+	Name string
+}
+
+func (e Entity) String() string {
+	return fmt.Sprintf("%s %s", e.Type, e.Name)
+}
+
+// Snapshot represents the schedules of the groups and teachers fetched at once.
+type Snapshot struct {
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Schedules  map[Entity]*Schedule
+	FetchedAt  map[Entity]time.Time
+}
+
+// NewSnapshot returns an empty *Snapshot.
+func NewSnapshot() *Snapshot {
+	return &Snapshot{
+		Schedules: map[Entity]*Schedule{},
+		FetchedAt: map[Entity]time.Time{},
+	}
+}
+
+// Add adds the schedule of the entity fetched at fetchedAt to the snapshot.
+func (s *Snapshot) Add(entity Entity, schedule *Schedule, fetchedAt time.Time) {
+	s.Schedules[entity] = schedule
+	s.FetchedAt[entity] = fetchedAt
+}
@@ -0,0 +1,115 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeSlot represents the time of one lesson. Start and End are counted from the beginning of the day.
+type TimeSlot struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+func (ts TimeSlot) String() string {
+	return fmt.Sprintf("%s-%s", formatDayTime(ts.Start), formatDayTime(ts.End))
+}
+
+// TimeTable represents the bell schedule: the time slots of the lessons in order. Different campuses or study forms
+// (e.g. evening classes) can use their own TimeTable.
+type TimeTable struct {
+	Name  string
+	Slots []TimeSlot
+}
+
+// DefaultTimeTable is the bell schedule of the main UlSTU campus.
+var DefaultTimeTable = MustTimeTable("Основное расписание звонков",
+	"08:30-09:50", "10:00-11:20", "11:30-12:50", "13:30-14:50", "15:00-16:20", "16:30-17:50", "18:00-19:20",
+	"19:30-20:50")
+
+// NewTimeTable returns *TimeTable with the time slots specified in the "hh:mm-hh:mm" format.
+func NewTimeTable(name string, slots ...string) (*TimeTable, error) {
+	tt := &TimeTable{Name: name, Slots: make([]TimeSlot, 0, len(slots))}
+	for _, slot := range slots {
+		startEnd := strings.Split(slot, "-")
+		if len(startEnd) != 2 {
+			return nil, &IncorrectTimeSlotError{Slot: slot}
+		}
+
+		start, err := parseDayTime(startEnd[0])
+		if err != nil {
+			return nil, &IncorrectTimeSlotError{Slot: slot}
+		}
+		end, err := parseDayTime(startEnd[1])
+		if err != nil || end <= start {
+			return nil, &IncorrectTimeSlotError{Slot: slot}
+		}
+
+		tt.Slots = append(tt.Slots, TimeSlot{Start: start, End: end})
+	}
+	return tt, nil
+}
+
+// MustTimeTable is like NewTimeTable but panics if the time slots cannot be parsed.
+func MustTimeTable(name string, slots ...string) *TimeTable {
+	tt, err := NewTimeTable(name, slots...)
+	if err != nil {
+		panic(err)
+	}
+	return tt
+}
+
+// Slot returns the time slot of the lesson with the given Duration. Returns false if the time table has no such slot.
+func (tt *TimeTable) Slot(d Duration) (TimeSlot, bool) {
+	if d < 0 || int(d) >= len(tt.Slots) {
+		return TimeSlot{}, false
+	}
+	return tt.Slots[d], true
+}
+
+// Slot returns the time slot of the lesson resolved against the time table. If tt is nil, DefaultTimeTable is used.
+func (d Duration) Slot(tt *TimeTable) (TimeSlot, bool) {
+	if tt == nil {
+		tt = DefaultTimeTable
+	}
+	return tt.Slot(d)
+}
+
+// StringIn returns the string representation of the lesson time resolved against the time table. Returns an empty
+// string if the time table has no such slot.
+func (d Duration) StringIn(tt *TimeTable) string {
+	slot, ok := d.Slot(tt)
+	if !ok {
+		return ""
+	}
+	return slot.String()
+}
+
+// TimeRange returns the start and the end of the lesson on the day of the date. The location of the date is used.
+// Returns false if the time table has no such slot.
+func (d Duration) TimeRange(date time.Time, tt *TimeTable) (time.Time, time.Time, bool) {
+	slot, ok := d.Slot(tt)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+
+	year, month, day := date.Date()
+	dayStart := time.Date(year, month, day, 0, 0, 0, 0, date.Location())
+	return dayStart.Add(slot.Start), dayStart.Add(slot.End), true
+}
+
+// parseDayTime returns the time elapsed since the beginning of the day from the string in the "hh:mm" format.
+func parseDayTime(dayTime string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(dayTime))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// formatDayTime returns the string representation of the time elapsed since the beginning of the day in the
+// "hh:mm" format.
+func formatDayTime(dayTime time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(dayTime.Hours()), int(dayTime.Minutes())%60)
+}
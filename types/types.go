@@ -4,17 +4,35 @@ package types
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 )
 
-// Schedule represents the full schedule that contains two school Weeks.
+// Schedule represents the full schedule that contains the published school Weeks. Usually these are two alternating
+// weeks (odd and even) that rotate during the semester, but the session schedule contains only one week, and
+// sometimes more weeks are published ahead.
 //easyjson:json
 type Schedule struct {
-	Weeks [2]Week `json:"weeks"`
+	Weeks []Week `json:"weeks"`
 }
 
-// Week represents the school week (one of two schedule tables) that contains seventh Days.
+// WeekParity is the parity of the school week number. The university schedule alternates between odd and even weeks.
+type WeekParity int
+
+const (
+	Odd WeekParity = iota
+	Even
+)
+
+func (wp WeekParity) String() string {
+	if wp == Even {
+		return "чётная"
+	}
+	return "нечётная"
+}
+
+// Week represents the school week (one of the schedule tables) that contains seventh Days.
 type Week struct {
 	Number    int       `json:"number"`
 	DateStart time.Time `json:"date_start"`
@@ -22,10 +40,45 @@ type Week struct {
 	Days      [7]Day    `json:"days"`
 }
 
-// Day represents the school day (the row in the schedule table) that contains eight Lessons.
+// Parity returns the parity of the school week number.
+func (w Week) Parity() WeekParity {
+	if w.Number%2 == 0 {
+		return Even
+	}
+	return Odd
+}
+
+// RotationLen returns the number of weeks after which the schedule repeats.
+func (s *Schedule) RotationLen() int {
+	return len(s.Weeks)
+}
+
+// WeekIdxByNumber returns the index of the week in Weeks that is used for the school week with the given number.
+// If the week was not published, the week that takes its place in the rotation is returned. Returns false if the
+// schedule has no weeks.
+func (s *Schedule) WeekIdxByNumber(number int) (int, bool) {
+	if len(s.Weeks) == 0 {
+		return 0, false
+	}
+
+	for weekIdx, week := range s.Weeks {
+		if week.Number == number {
+			return weekIdx, true
+		}
+	}
+
+	weekIdx := (number - s.Weeks[0].Number) % len(s.Weeks)
+	if weekIdx < 0 {
+		weekIdx += len(s.Weeks)
+	}
+	return weekIdx, true
+}
+
+// Day represents the school day (the row in the schedule table) that contains Lessons. The index of the Lesson is
+// the number of its time slot in the TimeTable.
 type Day struct {
-	WeekNumber int       `json:"week_number"`
-	Lessons    [8]Lesson `json:"lessons"`
+	WeekNumber int      `json:"week_number"`
+	Lessons    []Lesson `json:"lessons"`
 }
 
 // Lesson represents the lesson (the cell in the schedule table) that can contain one or more SubLessons.
@@ -43,7 +96,33 @@ type SubLesson struct {
 	Teacher  string     `json:"teacher"`
 	Room     string     `json:"room"`
 	Practice string     `json:"practice"`
-	SubGroup string     `json:"sub_group"`
+	SubGroup string     `json:"sub_group"` // raw subgroup as published, e.g. "1 п/г"
+	// SubGroupNum is the number of the subgroup parsed from SubGroup, 0 if the SubLesson is common for the group
+	SubGroupNum int `json:"sub_group_num"`
+}
+
+// SubGroupNumber returns the number of the subgroup of the SubLesson, 0 if it is common for the group. If SubGroupNum
+// is not set, the number is parsed from SubGroup, so the schedules saved before SubGroupNum appeared are supported.
+func (sl SubLesson) SubGroupNumber() int {
+	if sl.SubGroupNum != 0 {
+		return sl.SubGroupNum
+	}
+	return ParseSubGroupNum(sl.SubGroup)
+}
+
+// ParseSubGroupNum returns the number of the subgroup from its string representation, e.g. 2 for "2 п/г". Returns 0
+// if the string does not start with the number.
+func ParseSubGroupNum(subGroup string) int {
+	fields := strings.Fields(subGroup)
+	if len(fields) == 0 {
+		return 0
+	}
+
+	num, err := strconv.Atoi(fields[0])
+	if err != nil || num < 0 {
+		return 0
+	}
+	return num
 }
 
 // StringGroupLesson returns a string representation of Lesson based on the structure of the lesson display for groups.
@@ -132,8 +211,22 @@ type ScheduleType int
 const (
 	Group ScheduleType = iota
 	Teacher
+	Room
 )
 
+func (st ScheduleType) String() string {
+	switch st {
+	case Group:
+		return "group"
+	case Teacher:
+		return "teacher"
+	case Room:
+		return "room"
+	default:
+		return "unknown"
+	}
+}
+
 // LessonType is the type of the lesson. Can take 3 values: Lecture, Laboratory and Practice.
 type LessonType int
 
@@ -145,15 +238,24 @@ const (
 )
 
 func (lt LessonType) String() string {
-	return [...]string{"Лек.", "Лаб.", "Пр.", ""}[lt]
+	switch lt {
+	case Lecture:
+		return "Лек."
+	case Laboratory:
+		return "Лаб."
+	case Practice:
+		return "Пр."
+	default:
+		return ""
+	}
 }
 
-// Duration represents the lesson's duration.
+// Duration represents the lesson's duration: the number of the lesson's time slot in the TimeTable.
 type Duration int
 
+// String returns the lesson time resolved against DefaultTimeTable.
 func (d Duration) String() string {
-	return [...]string{"08:30-09:50", "10:00-11:20", "11:30-12:50", "13:30-14:50", "15:00-16:20", "16:30-17:50",
-		"18:00-19:20", "19:30-20:50"}[d]
+	return d.StringIn(DefaultTimeTable)
 }
 
 // StringGroup returns a string representation of SubLesson based on the structure of the lesson display for groups.
@@ -198,6 +300,14 @@ func (sl SubLesson) StringRoomSubLesson() string {
 	return ""
 }
 
+// StringTeacherSubLesson returns a string representation of SubLesson based on the structure of the lesson display for teachers.
+func (sl SubLesson) StringTeacherSubLesson() string {
+	if sl.Name != "" {
+		return fmt.Sprintf("%s %s %s, аудитория %s", sl.Type, sl.Name, sl.Group, sl.Room)
+	}
+	return ""
+}
+
 func (l Lesson) GetGroupsTeacherLesson() string {
 	var groups strings.Builder
 	for indexSubLesson, subLesson := range l.SubLessons {
@@ -209,3 +319,75 @@ func (l Lesson) GetGroupsTeacherLesson() string {
 	}
 	return groups.String()
 }
+
+// Candidate is the group or teacher name found by the name resolver.
+type Candidate struct {
+	Name  string
+	Score float64 // similarity to the requested name from 0 to 1
+}
+
+// StudyForm is the form of study of the group.
+type StudyForm int
+
+const (
+	FullTime   StudyForm = iota // очная
+	PartTime                    // очно-заочная
+	Extramural                  // заочная
+)
+
+func (sf StudyForm) String() string {
+	switch sf {
+	case FullTime:
+		return "очная"
+	case PartTime:
+		return "очно-заочная"
+	case Extramural:
+		return "заочная"
+	default:
+		return ""
+	}
+}
+
+// Degree is the degree level of the group.
+type Degree int
+
+const (
+	Bachelor Degree = iota
+	Master
+	Specialist
+	UnknownDegree
+)
+
+func (d Degree) String() string {
+	switch d {
+	case Bachelor:
+		return "бакалавриат"
+	case Master:
+		return "магистратура"
+	case Specialist:
+		return "специалитет"
+	default:
+		return ""
+	}
+}
+
+// GroupInfo represents the group from the lists of groups on UlSTU site.
+type GroupInfo struct {
+	Name      string    `json:"name"`
+	Part      int       `json:"part"`      // number of the part of the schedule ("Часть 1" - "Часть 4") that lists the group
+	Faculties string    `json:"faculties"` // faculties of the part of the schedule
+	Course    int       `json:"course"`
+	StudyForm StudyForm `json:"study_form"`
+	Degree    Degree    `json:"degree"`
+	URL       string    `json:"url"` // url to the group's schedule
+}
+
+// TeacherInfo represents the teacher from the list of teachers on UlSTU site.
+type TeacherInfo struct {
+	Name       string   `json:"name"`       // short name, e.g. "Зенкина С М"
+	Department string   `json:"department"` // empty if the list does not contain the department
+	Position   string   `json:"position"`   // empty if the list does not contain the position
+	Details    []string `json:"details"`    // all the information listed next to the name
+	IsPseudo   bool     `json:"is_pseudo"`  // true for the names used instead of the teacher, e.g. "Преподаватели кафедры"
+	URL        string   `json:"url"`        // url to the teacher's schedule
+}
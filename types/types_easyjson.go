@@ -39,16 +39,22 @@ func easyjson6601e8cdDecodeGithubComUlstuScheduleParserTypes(in *jlexer.Lexer, o
 		case "weeks":
 			if in.IsNull() {
 				in.Skip()
+				out.Weeks = nil
 			} else {
 				in.Delim('[')
-				v1 := 0
-				for !in.IsDelim(']') {
-					if v1 < 2 {
-						easyjson6601e8cdDecodeGithubComUlstuScheduleParserTypes1(in, &(out.Weeks)[v1])
-						v1++
+				if out.Weeks == nil {
+					if !in.IsDelim(']') {
+						out.Weeks = make([]Week, 0, 0)
 					} else {
-						in.SkipRecursive()
+						out.Weeks = []Week{}
 					}
+				} else {
+					out.Weeks = (out.Weeks)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 Week
+					easyjson6601e8cdDecodeGithubComUlstuScheduleParserTypes1(in, &v1)
+					out.Weeks = append(out.Weeks, v1)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -70,14 +76,18 @@ func easyjson6601e8cdEncodeGithubComUlstuScheduleParserTypes(out *jwriter.Writer
 	{
 		const prefix string = ",\"weeks\":"
 		out.RawString(prefix[1:])
-		out.RawByte('[')
-		for v2 := range in.Weeks {
-			if v2 > 0 {
-				out.RawByte(',')
+		if in.Weeks == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Weeks {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				easyjson6601e8cdEncodeGithubComUlstuScheduleParserTypes1(out, v3)
 			}
-			easyjson6601e8cdEncodeGithubComUlstuScheduleParserTypes1(out, (in.Weeks)[v2])
+			out.RawByte(']')
 		}
-		out.RawByte(']')
 	}
 	out.RawByte('}')
 }
@@ -125,11 +135,11 @@ func easyjson6601e8cdDecodeGithubComUlstuScheduleParserTypes1(in *jlexer.Lexer,
 				in.Skip()
 			} else {
 				in.Delim('[')
-				v3 := 0
+				v4 := 0
 				for !in.IsDelim(']') {
-					if v3 < 7 {
-						easyjson6601e8cdDecodeGithubComUlstuScheduleParserTypes2(in, &(out.Days)[v3])
-						v3++
+					if v4 < 7 {
+						easyjson6601e8cdDecodeGithubComUlstuScheduleParserTypes2(in, &(out.Days)[v4])
+						v4++
 					} else {
 						in.SkipRecursive()
 					}
@@ -170,11 +180,11 @@ func easyjson6601e8cdEncodeGithubComUlstuScheduleParserTypes1(out *jwriter.Write
 		const prefix string = ",\"days\":"
 		out.RawString(prefix)
 		out.RawByte('[')
-		for v4 := range in.Days {
-			if v4 > 0 {
+		for v5 := range in.Days {
+			if v5 > 0 {
 				out.RawByte(',')
 			}
-			easyjson6601e8cdEncodeGithubComUlstuScheduleParserTypes2(out, (in.Days)[v4])
+			easyjson6601e8cdEncodeGithubComUlstuScheduleParserTypes2(out, (in.Days)[v5])
 		}
 		out.RawByte(']')
 	}
@@ -204,16 +214,22 @@ func easyjson6601e8cdDecodeGithubComUlstuScheduleParserTypes2(in *jlexer.Lexer,
 		case "lessons":
 			if in.IsNull() {
 				in.Skip()
+				out.Lessons = nil
 			} else {
 				in.Delim('[')
-				v5 := 0
-				for !in.IsDelim(']') {
-					if v5 < 8 {
-						easyjson6601e8cdDecodeGithubComUlstuScheduleParserTypes3(in, &(out.Lessons)[v5])
-						v5++
+				if out.Lessons == nil {
+					if !in.IsDelim(']') {
+						out.Lessons = make([]Lesson, 0, 2)
 					} else {
-						in.SkipRecursive()
+						out.Lessons = []Lesson{}
 					}
+				} else {
+					out.Lessons = (out.Lessons)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v6 Lesson
+					easyjson6601e8cdDecodeGithubComUlstuScheduleParserTypes3(in, &v6)
+					out.Lessons = append(out.Lessons, v6)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -240,14 +256,18 @@ func easyjson6601e8cdEncodeGithubComUlstuScheduleParserTypes2(out *jwriter.Write
 	{
 		const prefix string = ",\"lessons\":"
 		out.RawString(prefix)
-		out.RawByte('[')
-		for v6 := range in.Lessons {
-			if v6 > 0 {
-				out.RawByte(',')
+		if in.Lessons == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v7, v8 := range in.Lessons {
+				if v7 > 0 {
+					out.RawByte(',')
+				}
+				easyjson6601e8cdEncodeGithubComUlstuScheduleParserTypes3(out, v8)
 			}
-			easyjson6601e8cdEncodeGithubComUlstuScheduleParserTypes3(out, (in.Lessons)[v6])
+			out.RawByte(']')
 		}
-		out.RawByte(']')
 	}
 	out.RawByte('}')
 }
@@ -286,9 +306,9 @@ func easyjson6601e8cdDecodeGithubComUlstuScheduleParserTypes3(in *jlexer.Lexer,
 					out.SubLessons = (out.SubLessons)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v7 SubLesson
-					easyjson6601e8cdDecodeGithubComUlstuScheduleParserTypes4(in, &v7)
-					out.SubLessons = append(out.SubLessons, v7)
+					var v9 SubLesson
+					easyjson6601e8cdDecodeGithubComUlstuScheduleParserTypes4(in, &v9)
+					out.SubLessons = append(out.SubLessons, v9)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -314,11 +334,11 @@ func easyjson6601e8cdEncodeGithubComUlstuScheduleParserTypes3(out *jwriter.Write
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v8, v9 := range in.SubLessons {
-				if v8 > 0 {
+			for v10, v11 := range in.SubLessons {
+				if v10 > 0 {
 					out.RawByte(',')
 				}
-				easyjson6601e8cdEncodeGithubComUlstuScheduleParserTypes4(out, v9)
+				easyjson6601e8cdEncodeGithubComUlstuScheduleParserTypes4(out, v11)
 			}
 			out.RawByte(']')
 		}
@@ -360,6 +380,8 @@ func easyjson6601e8cdDecodeGithubComUlstuScheduleParserTypes4(in *jlexer.Lexer,
 			out.Practice = string(in.String())
 		case "sub_group":
 			out.SubGroup = string(in.String())
+		case "sub_group_num":
+			out.SubGroupNum = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -414,5 +436,10 @@ func easyjson6601e8cdEncodeGithubComUlstuScheduleParserTypes4(out *jwriter.Write
 		out.RawString(prefix)
 		out.String(string(in.SubGroup))
 	}
+	{
+		const prefix string = ",\"sub_group_num\":"
+		out.RawString(prefix)
+		out.Int(int(in.SubGroupNum))
+	}
 	out.RawByte('}')
 }
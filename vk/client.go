@@ -0,0 +1,273 @@
+package vk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultAPIURL is the URL of the VK API methods.
+	DefaultAPIURL = "https://api.vk.com/method"
+	// DefaultVersion is the version of the VK API used by the client.
+	DefaultVersion = "5.131"
+)
+
+// Keyboard is the keyboard attached to the message.
+type Keyboard struct {
+	Inline  bool               `json:"inline"`
+	Buttons [][]KeyboardButton `json:"buttons"`
+}
+
+// KeyboardButton is the button of the keyboard.
+type KeyboardButton struct {
+	Action ButtonAction `json:"action"`
+}
+
+// ButtonAction is the action of the button. The callback buttons send the message_event with the payload to the bot
+// without a new message in the chat.
+type ButtonAction struct {
+	Type    string `json:"type"`
+	Label   string `json:"label"`
+	Payload string `json:"payload,omitempty"` // JSON object
+}
+
+// APIError is returned when VK API rejects the request.
+type APIError struct {
+	Method  string
+	Code    int    `json:"error_code"`
+	Message string `json:"error_msg"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("vk %s: %d %s", e.Method, e.Code, e.Message)
+}
+
+// apiResponse is the response of VK API.
+type apiResponse struct {
+	Response json.RawMessage `json:"response"`
+	Error    *APIError       `json:"error"`
+}
+
+// Client makes the requests to VK API on behalf of the community.
+type Client struct {
+	// APIURL is the URL of the API methods, DefaultAPIURL by default
+	APIURL string
+	// Version is the version of the API, DefaultVersion by default
+	Version string
+	// HTTPClient is used to make the requests, http.DefaultClient if nil
+	HTTPClient *http.Client
+
+	token string
+}
+
+// NewClient returns *Client with the access token of the community.
+func NewClient(token string) *Client {
+	return &Client{APIURL: DefaultAPIURL, Version: DefaultVersion, token: token}
+}
+
+// SendMessage sends the message with the attachments and the keyboard to the peer. The keyboard may be nil. Returns
+// the ID of the message.
+func (c *Client) SendMessage(ctx context.Context, peerID int64, text, attachment string, keyboard *Keyboard) (
+	int64, error) {
+	params := url.Values{}
+	params.Set("peer_id", strconv.FormatInt(peerID, 10))
+	// the messages with the same random_id are sent only once
+	params.Set("random_id", strconv.FormatInt(int64(rand.Int31()), 10))
+	params.Set("message", text)
+	if attachment != "" {
+		params.Set("attachment", attachment)
+	}
+	if err := setKeyboard(params, keyboard); err != nil {
+		return 0, err
+	}
+
+	var messageID int64
+	return messageID, c.call(ctx, "messages.send", params, &messageID)
+}
+
+// EditMessage replaces the text and the keyboard of the message in the conversation. The keyboard may be nil.
+func (c *Client) EditMessage(ctx context.Context, peerID, conversationMessageID int64, text string,
+	keyboard *Keyboard) error {
+	params := url.Values{}
+	params.Set("peer_id", strconv.FormatInt(peerID, 10))
+	params.Set("conversation_message_id", strconv.FormatInt(conversationMessageID, 10))
+	params.Set("message", text)
+	if err := setKeyboard(params, keyboard); err != nil {
+		return err
+	}
+	return c.call(ctx, "messages.edit", params, nil)
+}
+
+// SendMessageEventAnswer notifies the messenger that the press of the callback button is handled.
+func (c *Client) SendMessageEventAnswer(ctx context.Context, eventID string, userID, peerID int64) error {
+	params := url.Values{}
+	params.Set("event_id", eventID)
+	params.Set("user_id", strconv.FormatInt(userID, 10))
+	params.Set("peer_id", strconv.FormatInt(peerID, 10))
+	return c.call(ctx, "messages.sendMessageEventAnswer", params, nil)
+}
+
+// UploadPhoto uploads the PNG image for the message to the peer. Returns the attachment of the photo, e.g.
+// "photo-123_456_abc".
+func (c *Client) UploadPhoto(ctx context.Context, peerID int64, photo []byte) (string, error) {
+	params := url.Values{}
+	params.Set("peer_id", strconv.FormatInt(peerID, 10))
+
+	uploadServer := struct {
+		UploadURL string `json:"upload_url"`
+	}{}
+	if err := c.call(ctx, "photos.getMessagesUploadServer", params, &uploadServer); err != nil {
+		return "", err
+	}
+
+	uploaded, err := c.upload(ctx, uploadServer.UploadURL, photo)
+	if err != nil {
+		return "", err
+	}
+
+	params = url.Values{}
+	params.Set("server", strconv.FormatInt(uploaded.Server, 10))
+	params.Set("photo", uploaded.Photo)
+	params.Set("hash", uploaded.Hash)
+
+	var saved []struct {
+		ID        int64  `json:"id"`
+		OwnerID   int64  `json:"owner_id"`
+		AccessKey string `json:"access_key"`
+	}
+	if err = c.call(ctx, "photos.saveMessagesPhoto", params, &saved); err != nil {
+		return "", err
+	}
+	if len(saved) == 0 {
+		return "", errors.New("vk photos.saveMessagesPhoto: no photos saved")
+	}
+
+	attachment := fmt.Sprintf("photo%d_%d", saved[0].OwnerID, saved[0].ID)
+	if saved[0].AccessKey != "" {
+		attachment += "_" + saved[0].AccessKey
+	}
+	return attachment, nil
+}
+
+// uploadedPhoto is the response of the upload server.
+type uploadedPhoto struct {
+	Server int64  `json:"server"`
+	Photo  string `json:"photo"`
+	Hash   string `json:"hash"`
+}
+
+// upload sends the photo to the upload server.
+func (c *Client) upload(ctx context.Context, uploadURL string, photo []byte) (*uploadedPhoto, error) {
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+
+	photoWriter, err := mw.CreateFormFile("photo", "schedule.png")
+	if err != nil {
+		return nil, err
+	}
+	if _, err = photoWriter.Write(photo); err != nil {
+		return nil, err
+	}
+	if err = mw.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	res, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("vk upload: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	uploaded := &uploadedPhoto{}
+	if err = json.NewDecoder(res.Body).Decode(uploaded); err != nil {
+		return nil, fmt.Errorf("vk upload: %d %s: %w", res.StatusCode, http.StatusText(res.StatusCode), err)
+	}
+	// the upload server responds with the empty photo if the image is rejected
+	if uploaded.Photo == "" || uploaded.Photo == "[]" {
+		return nil, errors.New("vk upload: photo is rejected")
+	}
+	return uploaded, nil
+}
+
+// call makes the request to the method and decodes its response into result if it is not nil.
+func (c *Client) call(ctx context.Context, method string, params url.Values, result interface{}) error {
+	apiURL, version := c.APIURL, c.Version
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	if version == "" {
+		version = DefaultVersion
+	}
+
+	// the token is sent in the body, so it is not revealed by the errors with the URL
+	params.Set("access_token", c.token)
+	params.Set("v", version)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL+"/"+method,
+		strings.NewReader(params.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := c.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("vk %s: %w", method, err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	apiRes := &apiResponse{}
+	if err = json.NewDecoder(res.Body).Decode(apiRes); err != nil {
+		return fmt.Errorf("vk %s: %d %s: %w", method, res.StatusCode, http.StatusText(res.StatusCode), err)
+	}
+	if apiRes.Error != nil {
+		apiRes.Error.Method = method
+		return apiRes.Error
+	}
+
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(apiRes.Response, result)
+}
+
+// httpClient returns the client used to make the requests.
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return c.HTTPClient
+}
+
+// setKeyboard adds the keyboard to the params if it is not nil.
+func setKeyboard(params url.Values, keyboard *Keyboard) error {
+	if keyboard == nil {
+		return nil
+	}
+
+	keyboardJSON, err := json.Marshal(keyboard)
+	if err != nil {
+		return err
+	}
+	params.Set("keyboard", string(keyboardJSON))
+	return nil
+}
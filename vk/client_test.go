@@ -0,0 +1,163 @@
+package vk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testToken = "vk1.a.secret"
+
+// apiCall is the request received by the stub of VK API.
+type apiCall struct {
+	Method string
+	Params url.Values
+	Photo  []byte
+}
+
+// stubAPI is VK API and the upload server that record the requests and respond with the results set by the tests.
+type stubAPI struct {
+	URL string
+
+	mu      sync.Mutex
+	calls   []apiCall
+	results map[string]string // method -> JSON response
+}
+
+func newStubAPI(t *testing.T) (*stubAPI, *Client) {
+	t.Helper()
+
+	api := &stubAPI{results: map[string]string{}}
+	ts := httptest.NewServer(api)
+	t.Cleanup(ts.Close)
+	api.URL = ts.URL
+
+	api.results["photos.getMessagesUploadServer"] = `{"response":{"upload_url":"` + ts.URL + `/upload"}}`
+	api.results["upload"] = `{"server":12,"photo":"[{\"photo\":\"abc\"}]","hash":"h"}`
+	api.results["photos.saveMessagesPhoto"] = `{"response":[{"id":456,"owner_id":-123,"access_key":"key"}]}`
+
+	client := NewClient(testToken)
+	client.APIURL = ts.URL + "/method"
+	return api, client
+}
+
+func (a *stubAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	call := apiCall{}
+	if r.URL.Path == "/upload" {
+		call.Method = "upload"
+		file, _, err := r.FormFile("photo")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		call.Photo, _ = ioutil.ReadAll(file)
+		_ = file.Close()
+	} else {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("access_token") != testToken {
+			_, _ = w.Write([]byte(`{"error":{"error_code":5,"error_msg":"User authorization failed"}}`))
+			return
+		}
+		call.Method = strings.TrimPrefix(r.URL.Path, "/method/")
+		call.Params = r.PostForm
+	}
+
+	a.mu.Lock()
+	a.calls = append(a.calls, call)
+	result, ok := a.results[call.Method]
+	a.mu.Unlock()
+
+	if !ok {
+		result = `{"response":1}`
+	}
+	_, _ = w.Write([]byte(result))
+}
+
+func (a *stubAPI) setResult(method, result string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.results[method] = result
+}
+
+func (a *stubAPI) getCalls() []apiCall {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]apiCall(nil), a.calls...)
+}
+
+func TestClientSendMessage(t *testing.T) {
+	api, client := newStubAPI(t)
+	api.setResult("messages.send", `{"response":42}`)
+
+	keyboard := &Keyboard{Inline: true, Buttons: [][]KeyboardButton{{{Action: ButtonAction{Type: "callback",
+		Label: "▶", Payload: `{"data":"day:1:0:АТсд-21"}`}}}}}
+	messageID, err := client.SendMessage(context.Background(), 7, "hi", "photo-1_2", keyboard)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 42, messageID)
+
+	calls := api.getCalls()
+	if assert.Len(t, calls, 1) {
+		assert.EqualValues(t, "messages.send", calls[0].Method)
+		assert.EqualValues(t, "7", calls[0].Params.Get("peer_id"))
+		assert.EqualValues(t, "hi", calls[0].Params.Get("message"))
+		assert.EqualValues(t, "photo-1_2", calls[0].Params.Get("attachment"))
+		assert.EqualValues(t, DefaultVersion, calls[0].Params.Get("v"))
+		assert.NotEmpty(t, calls[0].Params.Get("random_id"))
+
+		sentKeyboard := &Keyboard{}
+		assert.Nil(t, json.Unmarshal([]byte(calls[0].Params.Get("keyboard")), sentKeyboard))
+		assert.EqualValues(t, keyboard, sentKeyboard)
+	}
+}
+
+func TestClientUploadPhoto(t *testing.T) {
+	api, client := newStubAPI(t)
+
+	attachment, err := client.UploadPhoto(context.Background(), 7, []byte("png"))
+	assert.Nil(t, err)
+	assert.EqualValues(t, "photo-123_456_key", attachment)
+
+	calls := api.getCalls()
+	if assert.Len(t, calls, 3) {
+		assert.EqualValues(t, "photos.getMessagesUploadServer", calls[0].Method)
+		assert.EqualValues(t, "7", calls[0].Params.Get("peer_id"))
+		assert.EqualValues(t, "upload", calls[1].Method)
+		assert.EqualValues(t, []byte("png"), calls[1].Photo)
+		assert.EqualValues(t, "photos.saveMessagesPhoto", calls[2].Method)
+		assert.EqualValues(t, "12", calls[2].Params.Get("server"))
+		assert.EqualValues(t, `[{"photo":"abc"}]`, calls[2].Params.Get("photo"))
+		assert.EqualValues(t, "h", calls[2].Params.Get("hash"))
+	}
+
+	api.setResult("upload", `{"server":12,"photo":"[]","hash":"h"}`)
+	_, err = client.UploadPhoto(context.Background(), 7, []byte("png"))
+	assert.NotNil(t, err)
+}
+
+func TestClientErrors(t *testing.T) {
+	api, client := newStubAPI(t)
+	api.setResult("messages.send", `{"error":{"error_code":901,"error_msg":"Can't send messages for users without `+
+		`permission"}}`)
+
+	_, err := client.SendMessage(context.Background(), 7, "hi", "", nil)
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.EqualValues(t, &APIError{Method: "messages.send", Code: 901,
+			Message: "Can't send messages for users without permission"}, apiErr)
+	}
+
+	client = NewClient("wrong")
+	client.APIURL = api.URL + "/method"
+	err = client.SendMessageEventAnswer(context.Background(), "e", 1, 1)
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.EqualValues(t, 5, apiErr.Code)
+	}
+}
@@ -0,0 +1,282 @@
+// Package vk connects the schedule bot of the bot package to the community messages of VK. The events are received by
+// Callback API.
+package vk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/ulstu-schedule/parser/bot"
+)
+
+const (
+	confirmationEvent = "confirmation"
+	messageNewEvent   = "message_new"
+	messageEventEvent = "message_event"
+)
+
+// startCommand is the payload of the "Начать" button shown to the users that have not written to the community yet.
+const startCommand = "start"
+
+// commandsText is the text of the message with the keyboard of the commands sent after the response that has its own
+// inline keyboard.
+const commandsText = "Кнопки с командами добавлены под полем ввода сообщения"
+
+// commandButton is the text button of the persistent keyboard that sends the command of the bot.
+type commandButton struct {
+	label   string
+	command string
+}
+
+// commandButtons are the rows of the persistent keyboard.
+var commandButtons = [][]commandButton{
+	{{label: "Сегодня", command: "today"}, {label: "Завтра", command: "tomorrow"}},
+	{{label: "Неделя", command: "week"}, {label: "След. неделя", command: "nextweek"}},
+}
+
+// Event is the event sent to the callback server by VK.
+type Event struct {
+	Type    string          `json:"type"`
+	GroupID int64           `json:"group_id"`
+	Secret  string          `json:"secret"`
+	Object  json.RawMessage `json:"object"`
+}
+
+// NewMessage is the object of the message_new event.
+type NewMessage struct {
+	Message struct {
+		PeerID  int64  `json:"peer_id"`
+		FromID  int64  `json:"from_id"`
+		Text    string `json:"text"`
+		Payload string `json:"payload,omitempty"` // JSON object of the pressed text button
+	} `json:"message"`
+}
+
+// MessageEvent is the object of the message_event event, the press of the callback button.
+type MessageEvent struct {
+	UserID                int64           `json:"user_id"`
+	PeerID                int64           `json:"peer_id"`
+	EventID               string          `json:"event_id"`
+	Payload               json.RawMessage `json:"payload"`
+	ConversationMessageID int64           `json:"conversation_message_id"`
+}
+
+// buttonPayload is the payload of the buttons.
+type buttonPayload struct {
+	Command string `json:"command,omitempty"`
+	Data    string `json:"data,omitempty"`
+}
+
+// Bot is the callback server that passes the messages of the community to the handler and sends its responses.
+type Bot struct {
+	// Secret is the secret key set in the Callback API settings. The events with another key are rejected if it is
+	// not empty
+	Secret string
+	// OnError is called when the event cannot be handled or the response cannot be sent
+	OnError func(err error)
+
+	client       *Client
+	handler      *bot.Handler
+	confirmation string
+	wg           sync.WaitGroup
+}
+
+// NewBot returns *Bot that handles the events with handler and sends the responses by client. The confirmation is
+// the string the server must return to confirm its address in the Callback API settings.
+func NewBot(client *Client, handler *bot.Handler, confirmation string) *Bot {
+	return &Bot{client: client, handler: handler, confirmation: confirmation}
+}
+
+// ServeHTTP handles the event sent by Callback API. The event is confirmed before it is handled, because fetching and
+// rendering the schedule can take longer than VK waits for the response, and VK resends the unconfirmed events.
+func (b *Bot) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	event := Event{}
+	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if b.Secret != "" && event.Secret != b.Secret {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	if event.Type == confirmationEvent {
+		_, _ = w.Write([]byte(b.confirmation))
+		return
+	}
+
+	// VK resends the event until the server returns "ok", so the errors are only reported
+	_, _ = w.Write([]byte("ok"))
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		if err := b.HandleEvent(context.Background(), event); err != nil {
+			b.reportError(err)
+		}
+	}()
+}
+
+// Wait waits until the events received by ServeHTTP are handled.
+func (b *Bot) Wait() {
+	b.wg.Wait()
+}
+
+// HandleEvent handles the new message or the press of the button. The text buttons of the persistent keyboard send
+// the messages with the commands in the payload. The text response to the press of the callback button replaces the
+// message with the button. The other events are ignored.
+func (b *Bot) HandleEvent(ctx context.Context, event Event) error {
+	switch event.Type {
+	case messageNewEvent:
+		newMessage := NewMessage{}
+		if err := json.Unmarshal(event.Object, &newMessage); err != nil {
+			return err
+		}
+
+		text := getCommandText(newMessage.Message.Text)
+		payload := buttonPayload{}
+		if newMessage.Message.Payload != "" && json.Unmarshal([]byte(newMessage.Message.Payload), &payload) == nil &&
+			payload.Command != "" {
+			text = "/" + payload.Command
+		}
+		if text == "" {
+			return nil
+		}
+
+		res := b.handler.HandleMessage(newMessage.Message.PeerID, text)
+		return b.send(ctx, newMessage.Message.PeerID, res)
+	case messageEventEvent:
+		messageEvent := MessageEvent{}
+		if err := json.Unmarshal(event.Object, &messageEvent); err != nil {
+			return err
+		}
+		err := b.client.SendMessageEventAnswer(ctx, messageEvent.EventID, messageEvent.UserID, messageEvent.PeerID)
+		if err != nil {
+			return err
+		}
+
+		payload := buttonPayload{}
+		_ = json.Unmarshal(messageEvent.Payload, &payload)
+
+		res := b.handler.HandleCallback(payload.Data)
+		if res.Photo != nil {
+			return b.send(ctx, messageEvent.PeerID, res)
+		}
+
+		keyboard, err := convertKeyboard(res.Keyboard)
+		if err != nil {
+			return err
+		}
+		return b.client.EditMessage(ctx, messageEvent.PeerID, messageEvent.ConversationMessageID, res.Text, keyboard)
+	default:
+		return nil
+	}
+}
+
+// send sends the response to the peer, uploading its photo first. The keyboard of the commands is attached to the
+// response without its own keyboard, otherwise it is sent in the next message.
+func (b *Bot) send(ctx context.Context, peerID int64, res bot.Response) error {
+	keyboard, err := convertKeyboard(res.Keyboard)
+	if err != nil {
+		return err
+	}
+	if res.ShowCommands && keyboard == nil {
+		if keyboard, err = getCommandsKeyboard(); err != nil {
+			return err
+		}
+		res.ShowCommands = false
+	}
+
+	var attachment string
+	if res.Photo != nil {
+		if attachment, err = b.client.UploadPhoto(ctx, peerID, res.Photo); err != nil {
+			return err
+		}
+	}
+
+	if _, err = b.client.SendMessage(ctx, peerID, res.Text, attachment, keyboard); err != nil || !res.ShowCommands {
+		return err
+	}
+
+	commandsKeyboard, err := getCommandsKeyboard()
+	if err != nil {
+		return err
+	}
+	_, err = b.client.SendMessage(ctx, peerID, commandsText, "", commandsKeyboard)
+	return err
+}
+
+// reportError passes the error to OnError if it is set.
+func (b *Bot) reportError(err error) {
+	if b.OnError != nil {
+		b.OnError(err)
+	}
+}
+
+// getCommandText returns the text of the message without the mention of the community, which precedes the commands
+// in the group chats, e.g. "[club123|@ulstu_schedule] /today".
+func getCommandText(text string) string {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "[") {
+		if mentionEnd := strings.Index(text, "]"); mentionEnd != -1 {
+			text = strings.TrimSpace(text[mentionEnd+1:])
+			text = strings.TrimSpace(strings.TrimLeft(text, ",:"))
+		}
+	}
+	return text
+}
+
+// convertKeyboard converts the keyboard of the response to the inline keyboard with the callback buttons. Returns nil
+// if the keyboard is empty.
+func convertKeyboard(keyboard bot.Keyboard) (*Keyboard, error) {
+	if len(keyboard) == 0 {
+		return nil, nil
+	}
+
+	vkKeyboard := &Keyboard{Inline: true, Buttons: make([][]KeyboardButton, 0, len(keyboard))}
+	for _, row := range keyboard {
+		buttons := make([]KeyboardButton, 0, len(row))
+		for _, button := range row {
+			payload, err := json.Marshal(buttonPayload{Data: button.Data})
+			if err != nil {
+				return nil, err
+			}
+			buttons = append(buttons, KeyboardButton{Action: ButtonAction{
+				Type:    "callback",
+				Label:   button.Text,
+				Payload: string(payload),
+			}})
+		}
+		vkKeyboard.Buttons = append(vkKeyboard.Buttons, buttons)
+	}
+	return vkKeyboard, nil
+}
+
+// getCommandsKeyboard returns the persistent keyboard with the text buttons of the commands.
+func getCommandsKeyboard() (*Keyboard, error) {
+	vkKeyboard := &Keyboard{Buttons: make([][]KeyboardButton, 0, len(commandButtons))}
+	for _, row := range commandButtons {
+		buttons := make([]KeyboardButton, 0, len(row))
+		for _, button := range row {
+			payload, err := json.Marshal(buttonPayload{Command: button.command})
+			if err != nil {
+				return nil, err
+			}
+			buttons = append(buttons, KeyboardButton{Action: ButtonAction{
+				Type:    "text",
+				Label:   button.label,
+				Payload: string(payload),
+			}})
+		}
+		vkKeyboard.Buttons = append(vkKeyboard.Buttons, buttons)
+	}
+	return vkKeyboard, nil
+}
@@ -0,0 +1,189 @@
+package vk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/bot"
+	"github.com/ulstu-schedule/parser/mock"
+	"github.com/ulstu-schedule/parser/storage"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+func newTestBot(t *testing.T) (*stubAPI, *Bot) {
+	t.Helper()
+
+	store := storage.NewMemoryStorage()
+	err := store.Save(types.Entity{Type: types.Room, Name: "6-401"}, mock.TestRoomSchedule(t), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	api, client := newStubAPI(t)
+	return api, NewBot(client, bot.NewHandler(bot.NewMemoryBindings(), store), "confirm-code")
+}
+
+func newEvent(t *testing.T, eventType string, object interface{}) Event {
+	t.Helper()
+
+	data, err := json.Marshal(object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Event{Type: eventType, GroupID: 123, Object: data}
+}
+
+func TestBotHandleMessage(t *testing.T) {
+	api, b := newTestBot(t)
+
+	newMessage := NewMessage{}
+	newMessage.Message.PeerID = 7
+	newMessage.Message.Payload = `{"command":"start"}`
+	newMessage.Message.Text = "Начать"
+	assert.Nil(t, b.HandleEvent(context.Background(), newEvent(t, messageNewEvent, newMessage)))
+
+	newMessage.Message.Payload = ""
+	newMessage.Message.Text = "[club123|@ulstu_schedule], /room 6-401"
+	assert.Nil(t, b.HandleEvent(context.Background(), newEvent(t, messageNewEvent, newMessage)))
+
+	// the text button of the persistent keyboard
+	newMessage.Message.Payload = `{"command":"today"}`
+	newMessage.Message.Text = "Сегодня"
+	assert.Nil(t, b.HandleEvent(context.Background(), newEvent(t, messageNewEvent, newMessage)))
+
+	calls := api.getCalls()
+	if assert.Len(t, calls, 3) {
+		assert.EqualValues(t, "messages.send", calls[0].Method)
+		assert.True(t, strings.HasPrefix(calls[0].Params.Get("message"), "Бот показывает расписание УлГТУ"))
+
+		commandsKeyboard := &Keyboard{}
+		assert.Nil(t, json.Unmarshal([]byte(calls[0].Params.Get("keyboard")), commandsKeyboard))
+		assert.False(t, commandsKeyboard.Inline)
+		if assert.Len(t, commandsKeyboard.Buttons, 2) && assert.Len(t, commandsKeyboard.Buttons[1], 2) {
+			assert.EqualValues(t, ButtonAction{Type: "text", Label: "След. неделя", Payload: `{"command":"nextweek"}`},
+				commandsKeyboard.Buttons[1][1].Action)
+		}
+
+		assert.True(t, strings.HasPrefix(calls[2].Params.Get("message"), "Сначала выберите группу"))
+
+		assert.EqualValues(t, "messages.send", calls[1].Method)
+		assert.EqualValues(t, "7", calls[1].Params.Get("peer_id"))
+
+		keyboard := &Keyboard{}
+		assert.Nil(t, json.Unmarshal([]byte(calls[1].Params.Get("keyboard")), keyboard))
+		if assert.Len(t, keyboard.Buttons, 1) && assert.Len(t, keyboard.Buttons[0], 3) {
+			assert.True(t, keyboard.Inline)
+			assert.EqualValues(t, ButtonAction{Type: "callback", Label: "▶", Payload: `{"data":"day:1:2:6-401"}`},
+				keyboard.Buttons[0][2].Action)
+		}
+	}
+}
+
+func TestBotSendCommands(t *testing.T) {
+	api, b := newTestBot(t)
+
+	// the response with its own keyboard is followed by the message with the keyboard of the commands
+	res := bot.Response{
+		Text:         "Выбрано расписание: АТсд-21",
+		Keyboard:     bot.Keyboard{{{Text: "▶", Data: "day:1:0:АТсд-21"}}},
+		ShowCommands: true,
+	}
+	assert.Nil(t, b.send(context.Background(), 7, res))
+
+	calls := api.getCalls()
+	if assert.Len(t, calls, 2) {
+		keyboard := &Keyboard{}
+		assert.Nil(t, json.Unmarshal([]byte(calls[0].Params.Get("keyboard")), keyboard))
+		assert.True(t, keyboard.Inline)
+
+		assert.EqualValues(t, commandsText, calls[1].Params.Get("message"))
+		assert.Nil(t, json.Unmarshal([]byte(calls[1].Params.Get("keyboard")), keyboard))
+		assert.False(t, keyboard.Inline)
+		assert.EqualValues(t, "Сегодня", keyboard.Buttons[0][0].Action.Label)
+	}
+}
+
+func TestBotHandleMessageEvent(t *testing.T) {
+	api, b := newTestBot(t)
+
+	messageEvent := MessageEvent{UserID: 7, PeerID: 7, EventID: "e1", ConversationMessageID: 3,
+		Payload: json.RawMessage(`{"data":"day:1:2:6-401"}`)}
+	assert.Nil(t, b.HandleEvent(context.Background(), newEvent(t, messageEventEvent, messageEvent)))
+
+	messageEvent.EventID = "e2"
+	messageEvent.Payload = json.RawMessage(`{"data":"week:0:2:6-401"}`)
+	assert.Nil(t, b.HandleEvent(context.Background(), newEvent(t, messageEventEvent, messageEvent)))
+
+	calls := api.getCalls()
+	if assert.Len(t, calls, 7) {
+		assert.EqualValues(t, "messages.sendMessageEventAnswer", calls[0].Method)
+		assert.EqualValues(t, "e1", calls[0].Params.Get("event_id"))
+		assert.EqualValues(t, "messages.edit", calls[1].Method)
+		assert.EqualValues(t, "3", calls[1].Params.Get("conversation_message_id"))
+		assert.True(t, strings.HasPrefix(calls[1].Params.Get("message"), "Расписание кабинента 6-401 на завтра"))
+
+		assert.EqualValues(t, "messages.sendMessageEventAnswer", calls[2].Method)
+		assert.EqualValues(t, "photos.getMessagesUploadServer", calls[3].Method)
+		assert.EqualValues(t, "upload", calls[4].Method)
+		assert.True(t, strings.HasPrefix(string(calls[4].Photo), "\x89PNG"))
+		assert.EqualValues(t, "photos.saveMessagesPhoto", calls[5].Method)
+		assert.EqualValues(t, "messages.send", calls[6].Method)
+		assert.EqualValues(t, "photo-123_456_key", calls[6].Params.Get("attachment"))
+		assert.EqualValues(t, "Расписание 6-401 на текущую неделю", calls[6].Params.Get("message"))
+	}
+}
+
+func TestBotServeHTTP(t *testing.T) {
+	api, b := newTestBot(t)
+	b.Secret = "s"
+
+	serve := func(method, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		b.ServeHTTP(w, httptest.NewRequest(method, "/vk", strings.NewReader(body)))
+		return w
+	}
+
+	w := serve(http.MethodPost, `{"type":"confirmation","group_id":123,"secret":"s"}`)
+	assert.EqualValues(t, "confirm-code", w.Body.String())
+
+	w = serve(http.MethodPost, `{"type":"message_new","group_id":123,"secret":"s","object":{"message":`+
+		`{"peer_id":7,"text":"/help"}}}`)
+	assert.EqualValues(t, "ok", w.Body.String())
+	// the event is handled after it is confirmed
+	b.Wait()
+	assert.Len(t, api.getCalls(), 1)
+
+	// the errors are reported, but the event is confirmed
+	var reported error
+	b.OnError = func(err error) {
+		reported = err
+	}
+	api.setResult("messages.send", `{"error":{"error_code":901,"error_msg":"Can't send messages"}}`)
+	w = serve(http.MethodPost, `{"type":"message_new","group_id":123,"secret":"s","object":{"message":`+
+		`{"peer_id":7,"text":"/help"}}}`)
+	assert.EqualValues(t, "ok", w.Body.String())
+	b.Wait()
+	assert.NotNil(t, reported)
+
+	w = serve(http.MethodPost, `{"type":"message_new","group_id":123,"secret":"wrong","object":{}}`)
+	assert.EqualValues(t, http.StatusForbidden, w.Code)
+
+	w = serve(http.MethodPost, "{")
+	assert.EqualValues(t, http.StatusBadRequest, w.Code)
+
+	w = serve(http.MethodGet, "")
+	assert.EqualValues(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestGetCommandText(t *testing.T) {
+	assert.EqualValues(t, "/today", getCommandText(" /today "))
+	assert.EqualValues(t, "/today", getCommandText("[club123|@ulstu_schedule] /today"))
+	assert.EqualValues(t, "/group АТсд-21", getCommandText("[club123|Расписание УлГТУ], /group АТсд-21"))
+	assert.EqualValues(t, "", getCommandText("[club123|@ulstu_schedule]"))
+}
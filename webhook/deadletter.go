@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// DeadLetter is the webhook request that was not delivered after all the attempts.
+type DeadLetter struct {
+	URL      string          `json:"url"`
+	Payload  json.RawMessage `json:"payload"`
+	Attempts int             `json:"attempts"`
+	Error    string          `json:"error"`
+	FailedAt time.Time       `json:"failed_at"`
+}
+
+// DeadLetterLog keeps the undelivered webhook requests, so they can be inspected or redelivered later.
+type DeadLetterLog interface {
+	Add(letter DeadLetter) error
+}
+
+// FileDeadLetterLog appends the undelivered webhook requests to the file as JSON lines.
+type FileDeadLetterLog struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileDeadLetterLog returns *FileDeadLetterLog that writes to the file at path.
+func NewFileDeadLetterLog(path string) *FileDeadLetterLog {
+	return &FileDeadLetterLog{path: path}
+}
+
+// Add appends the letter to the file.
+func (l *FileDeadLetterLog) Add(letter DeadLetter) error {
+	data, err := json.Marshal(letter)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err = f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Letters returns all the letters from the file.
+func (l *FileDeadLetterLog) Letters() ([]DeadLetter, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	letters := make([]DeadLetter, 0)
+
+	f, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return letters, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 16*1024*1024)
+	for scanner.Scan() {
+		letter := DeadLetter{}
+		if err = json.Unmarshal(scanner.Bytes(), &letter); err != nil {
+			return nil, err
+		}
+		letters = append(letters, letter)
+	}
+	return letters, scanner.Err()
+}
@@ -0,0 +1,32 @@
+package webhook
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileDeadLetterLog(t *testing.T) {
+	l := NewFileDeadLetterLog(filepath.Join(t.TempDir(), "dead-letters.jsonl"))
+
+	letters, err := l.Letters()
+	assert.NoError(t, err)
+	assert.Empty(t, letters)
+
+	failedAt := time.Date(2024, 4, 16, 10, 0, 0, 0, time.UTC)
+	assert.NoError(t, l.Add(DeadLetter{URL: "http://localhost/a", Payload: json.RawMessage(`{"a":1}`), Attempts: 3,
+		Error: "timeout", FailedAt: failedAt}))
+	assert.NoError(t, l.Add(DeadLetter{URL: "http://localhost/b", Payload: json.RawMessage(`{"b":2}`), Attempts: 1,
+		Error: "400 Bad Request", FailedAt: failedAt}))
+
+	letters, err = l.Letters()
+	assert.NoError(t, err)
+	assert.Len(t, letters, 2)
+	assert.EqualValues(t, "http://localhost/a", letters[0].URL)
+	assert.JSONEq(t, `{"a":1}`, string(letters[0].Payload))
+	assert.EqualValues(t, 3, letters[0].Attempts)
+	assert.True(t, letters[1].FailedAt.Equal(failedAt))
+}
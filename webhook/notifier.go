@@ -0,0 +1,235 @@
+// Package webhook delivers the schedule change events to the registered HTTP endpoints.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ulstu-schedule/parser/schedule"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+const (
+	// SignatureHeader contains the HMAC-SHA256 signature of the request body: "sha256=<hex>"
+	SignatureHeader = "X-Schedule-Signature"
+	// DeliveryHeader contains the unique ID of the delivery, the same for all the attempts
+	DeliveryHeader = "X-Schedule-Delivery"
+	// EventHeader contains the type of the event
+	EventHeader = "X-Schedule-Event"
+
+	changeEventType    = "schedule.changed"
+	signaturePrefix    = "sha256="
+	defaultMaxAttempts = 3
+	defaultRetryDelay  = time.Second
+	defaultTimeout     = 10 * time.Second
+)
+
+// Endpoint is the registered webhook URL with the secret used to sign the requests.
+type Endpoint struct {
+	URL    string
+	Secret string
+}
+
+// Notifier POSTs the schedule change events as JSON to the registered endpoints. The failed requests are retried
+// with the doubled delay, the requests that were not delivered after all the attempts are added to DeadLetters.
+type Notifier struct {
+	// Client is used to send the requests, http.Client with 10 seconds timeout if nil
+	Client *http.Client
+	// MaxAttempts is the number of attempts to deliver the request, 3 if not positive
+	MaxAttempts int
+	// RetryDelay is the delay before the first retry, 1 second if not positive
+	RetryDelay time.Duration
+	// DeadLetters keeps the undelivered requests, they are dropped if nil
+	DeadLetters DeadLetterLog
+
+	mu        sync.RWMutex
+	endpoints []Endpoint
+}
+
+// DeliveryError is returned when the request was not delivered to the endpoint after all the attempts.
+type DeliveryError struct {
+	URL      string
+	Attempts int
+	Err      error
+}
+
+func (e *DeliveryError) Error() string {
+	return fmt.Sprintf("webhook delivery to %s failed after %d attempts: %s", e.URL, e.Attempts, e.Err)
+}
+
+func (e *DeliveryError) Unwrap() error {
+	return e.Err
+}
+
+// NewNotifier returns *Notifier without the registered endpoints.
+func NewNotifier() *Notifier {
+	return &Notifier{Client: &http.Client{Timeout: defaultTimeout}}
+}
+
+// Register adds the endpoint, the requests to it are signed with secret. The endpoint with the same URL is replaced.
+func (n *Notifier) Register(url string, secret string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for endpointIdx := range n.endpoints {
+		if n.endpoints[endpointIdx].URL == url {
+			n.endpoints[endpointIdx].Secret = secret
+			return
+		}
+	}
+	n.endpoints = append(n.endpoints, Endpoint{URL: url, Secret: secret})
+}
+
+// Unregister removes the endpoint with the URL.
+func (n *Notifier) Unregister(url string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for endpointIdx := range n.endpoints {
+		if n.endpoints[endpointIdx].URL == url {
+			n.endpoints = append(n.endpoints[:endpointIdx], n.endpoints[endpointIdx+1:]...)
+			return
+		}
+	}
+}
+
+// Notify delivers the event to all the registered endpoints. Returns the first *DeliveryError if the event was not
+// delivered to some endpoints, the event is delivered to the rest anyway. If the undelivered requests could not be
+// added to DeadLetters, the first such error is added to the message of the returned error.
+func (n *Notifier) Notify(ctx context.Context, event schedule.ChangeEvent) error {
+	body, err := json.Marshal(NewEventPayload(event))
+	if err != nil {
+		return err
+	}
+
+	deliveryID, err := newDeliveryID()
+	if err != nil {
+		return err
+	}
+
+	n.mu.RLock()
+	endpoints := append([]Endpoint(nil), n.endpoints...)
+	n.mu.RUnlock()
+
+	var firstErr, deadLetterErr error
+	for _, endpoint := range endpoints {
+		attempts, err := n.deliver(ctx, endpoint, deliveryID, body)
+		if err == nil {
+			continue
+		}
+
+		deliveryErr := &DeliveryError{URL: endpoint.URL, Attempts: attempts, Err: err}
+		if firstErr == nil {
+			firstErr = deliveryErr
+		}
+		if n.DeadLetters != nil {
+			letter := DeadLetter{URL: endpoint.URL, Payload: body, Attempts: attempts, Error: err.Error(),
+				FailedAt: time.Now()}
+			if dlErr := n.DeadLetters.Add(letter); dlErr != nil && deadLetterErr == nil {
+				deadLetterErr = dlErr
+			}
+		}
+	}
+
+	if deadLetterErr != nil {
+		return fmt.Errorf("%w (dead letter is not saved: %v)", firstErr, deadLetterErr)
+	}
+	return firstErr
+}
+
+// deliver sends the request to the endpoint, retrying on the network errors and the server errors. Returns the number
+// of attempts made.
+func (n *Notifier) deliver(ctx context.Context, endpoint Endpoint, deliveryID string, body []byte) (int, error) {
+	maxAttempts := n.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+	retryDelay := n.RetryDelay
+	if retryDelay <= 0 {
+		retryDelay = defaultRetryDelay
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		var isRetryable bool
+		isRetryable, err = n.post(ctx, endpoint, deliveryID, body)
+		if err == nil || !isRetryable || attempt == maxAttempts {
+			return attempt, err
+		}
+
+		timer := time.NewTimer(retryDelay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt, ctx.Err()
+		case <-timer.C:
+		}
+		retryDelay *= 2
+	}
+}
+
+// post sends the signed request to the endpoint. Returns true if the failed request can be retried.
+func (n *Notifier) post(ctx context.Context, endpoint Endpoint, deliveryID string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, changeEventType)
+	req.Header.Set(DeliveryHeader, deliveryID)
+	req.Header.Set(SignatureHeader, Sign(endpoint.Secret, body))
+
+	client := n.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer func() {
+		_, _ = io.Copy(ioutil.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		isRetryable := res.StatusCode >= 500 || res.StatusCode == http.StatusTooManyRequests
+		return isRetryable, &types.StatusCodeError{URL: endpoint.URL, StatusCode: res.StatusCode,
+			StatusText: http.StatusText(res.StatusCode)}
+	}
+	return false, nil
+}
+
+// Sign returns the value of SignatureHeader for the request body signed with secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify returns true if signature is the valid value of SignatureHeader for the request body signed with secret,
+// otherwise - false. The receivers can use it to check that the request was sent by Notifier.
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// newDeliveryID returns the random ID of the delivery.
+func newDeliveryID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,193 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ulstu-schedule/parser/mock"
+	"github.com/ulstu-schedule/parser/schedule"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+const testSecret = "secret"
+
+func testChangeEvent(t *testing.T) schedule.ChangeEvent {
+	t.Helper()
+
+	oldSchedule := mock.TestGroupSchedule(t)
+	newSchedule := mock.TestGroupSchedule(t)
+	newSchedule.Weeks[0].Days[1].Lessons[1].SubLessons[0].Room = "6-402"
+	thursday := &newSchedule.Weeks[0].Days[3]
+	thursday.Lessons[5].SubLessons, thursday.Lessons[3].SubLessons = thursday.Lessons[3].SubLessons, nil
+
+	return schedule.ChangeEvent{
+		Entity:    types.Entity{Type: types.Group, Name: "АТсд-21"},
+		Changes:   schedule.Diff(oldSchedule, newSchedule),
+		Schedule:  newSchedule,
+		FetchedAt: time.Date(2024, 4, 16, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func newTestNotifier(t *testing.T) *Notifier {
+	t.Helper()
+
+	n := NewNotifier()
+	n.RetryDelay = time.Millisecond
+	n.DeadLetters = NewFileDeadLetterLog(filepath.Join(t.TempDir(), "dead-letters.jsonl"))
+	return n
+}
+
+// failingDeadLetterLog fails to add any letter.
+type failingDeadLetterLog struct{}
+
+func (failingDeadLetterLog) Add(DeadLetter) error {
+	return errors.New("disk is full")
+}
+
+func TestNotifierNotify(t *testing.T) {
+	t.Run("delivered", func(t *testing.T) {
+		var payload EventPayload
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.True(t, Verify(testSecret, body, r.Header.Get(SignatureHeader)))
+			assert.EqualValues(t, "application/json", r.Header.Get("Content-Type"))
+			assert.NotEmpty(t, r.Header.Get(DeliveryHeader))
+			assert.NoError(t, json.Unmarshal(body, &payload))
+		}))
+		defer server.Close()
+
+		n := newTestNotifier(t)
+		n.Register(server.URL, testSecret)
+
+		assert.NoError(t, n.Notify(context.Background(), testChangeEvent(t)))
+		assert.EqualValues(t, EntityPayload{Type: "group", Name: "АТсд-21"}, payload.Entity)
+		assert.Len(t, payload.Changes, 2)
+
+		roomChange := payload.Changes[0]
+		assert.EqualValues(t, "room changed", roomChange.Kind)
+		assert.EqualValues(t, 11, roomChange.Week)
+		assert.EqualValues(t, 1, roomChange.Day)
+		assert.EqualValues(t, 1, roomChange.Slot)
+		assert.EqualValues(t, "2024-04-16", roomChange.Date)
+		assert.EqualValues(t, "6-401", roomChange.Before.Room)
+		assert.EqualValues(t, "6-402", roomChange.After.Room)
+		assert.Nil(t, roomChange.PrevDay)
+
+		movedChange := payload.Changes[1]
+		assert.EqualValues(t, "moved", movedChange.Kind)
+		assert.EqualValues(t, 3, *movedChange.PrevSlot)
+		assert.EqualValues(t, 5, movedChange.Slot)
+		assert.Contains(t, movedChange.Text, "Пара перенесена")
+	})
+	t.Run("retried", func(t *testing.T) {
+		var requestsNum int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&requestsNum, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer server.Close()
+
+		n := newTestNotifier(t)
+		n.Register(server.URL, testSecret)
+
+		assert.NoError(t, n.Notify(context.Background(), testChangeEvent(t)))
+		assert.EqualValues(t, 3, requestsNum)
+	})
+	t.Run("dead letter", func(t *testing.T) {
+		var requestsNum int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requestsNum, 1)
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		n := newTestNotifier(t)
+		n.Register(server.URL, testSecret)
+
+		err := n.Notify(context.Background(), testChangeEvent(t))
+
+		var deliveryErr *DeliveryError
+		assert.True(t, errors.As(err, &deliveryErr))
+		assert.EqualValues(t, server.URL, deliveryErr.URL)
+		var statusCodeErr *types.StatusCodeError
+		assert.True(t, errors.As(err, &statusCodeErr))
+		assert.EqualValues(t, http.StatusBadRequest, statusCodeErr.StatusCode)
+		assert.EqualValues(t, "Bad Request", statusCodeErr.StatusText)
+		// the client errors are not retried
+		assert.EqualValues(t, 1, requestsNum)
+
+		letters, err := n.DeadLetters.(*FileDeadLetterLog).Letters()
+		assert.NoError(t, err)
+		assert.Len(t, letters, 1)
+		assert.EqualValues(t, server.URL, letters[0].URL)
+		assert.EqualValues(t, 1, letters[0].Attempts)
+
+		var payload EventPayload
+		assert.NoError(t, json.Unmarshal(letters[0].Payload, &payload))
+		assert.EqualValues(t, "АТсд-21", payload.Entity.Name)
+	})
+	t.Run("dead letter not saved", func(t *testing.T) {
+		var requestsNum int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requestsNum, 1)
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		n := newTestNotifier(t)
+		n.DeadLetters = failingDeadLetterLog{}
+		n.Register(server.URL+"/a", testSecret)
+		n.Register(server.URL+"/b", testSecret)
+
+		err := n.Notify(context.Background(), testChangeEvent(t))
+
+		// the event is delivered to the rest of the endpoints anyway
+		assert.EqualValues(t, 2, requestsNum)
+		var deliveryErr *DeliveryError
+		assert.True(t, errors.As(err, &deliveryErr))
+		assert.EqualValues(t, server.URL+"/a", deliveryErr.URL)
+		assert.Contains(t, err.Error(), "disk is full")
+	})
+	t.Run("unreachable", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+		server.Close()
+
+		n := newTestNotifier(t)
+		n.Register(server.URL, testSecret)
+
+		err := n.Notify(context.Background(), testChangeEvent(t))
+
+		var deliveryErr *DeliveryError
+		assert.True(t, errors.As(err, &deliveryErr))
+		assert.EqualValues(t, defaultMaxAttempts, deliveryErr.Attempts)
+	})
+}
+
+func TestNotifierRegister(t *testing.T) {
+	n := NewNotifier()
+	n.Register("http://localhost/a", "a")
+	n.Register("http://localhost/b", "b")
+	n.Register("http://localhost/a", "c")
+	n.Unregister("http://localhost/b")
+
+	assert.EqualValues(t, []Endpoint{{URL: "http://localhost/a", Secret: "c"}}, n.endpoints)
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"changes":[]}`)
+
+	assert.True(t, Verify(testSecret, body, Sign(testSecret, body)))
+	assert.False(t, Verify("other", body, Sign(testSecret, body)))
+	assert.False(t, Verify(testSecret, body, "sha256=00"))
+}
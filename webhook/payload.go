@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/ulstu-schedule/parser/schedule"
+	"github.com/ulstu-schedule/parser/types"
+)
+
+// EventPayload is the JSON body of the webhook request sent when the schedule has changed.
+type EventPayload struct {
+	Entity    EntityPayload   `json:"entity"`
+	FetchedAt time.Time       `json:"fetched_at"`
+	Changes   []ChangePayload `json:"changes"`
+}
+
+// EntityPayload is the group, teacher or room which schedule has changed.
+type EntityPayload struct {
+	Type string `json:"type"` // "group", "teacher" or "room"
+	Name string `json:"name"`
+}
+
+// ChangePayload is the change of the SubLesson. Day is the index of the day of the week starting from Monday, Slot is
+// the index of the lesson time slot.
+type ChangePayload struct {
+	Kind     string           `json:"kind"`
+	Week     int              `json:"week"`
+	Day      int              `json:"day"`
+	Slot     int              `json:"slot"`
+	Date     string           `json:"date,omitempty"`      // "2006-01-02"
+	PrevDay  *int             `json:"prev_day,omitempty"`  // set if Kind is "moved"
+	PrevSlot *int             `json:"prev_slot,omitempty"` // set if Kind is "moved"
+	Before   *types.SubLesson `json:"before"`
+	After    *types.SubLesson `json:"after"`
+	Text     string           `json:"text"` // human-readable description of the change in Russian
+}
+
+// NewEventPayload returns EventPayload built from the event of schedule.Watcher.
+func NewEventPayload(event schedule.ChangeEvent) EventPayload {
+	payload := EventPayload{
+		Entity:    EntityPayload{Type: event.Entity.Type.String(), Name: event.Entity.Name},
+		FetchedAt: event.FetchedAt,
+		Changes:   make([]ChangePayload, 0, len(event.Changes)),
+	}
+
+	for _, change := range event.Changes {
+		changePayload := ChangePayload{
+			Kind:   change.Kind.String(),
+			Week:   change.WeekNumber,
+			Day:    change.WeekDayNum,
+			Slot:   change.LessonNum,
+			Before: change.Before,
+			After:  change.After,
+			Text:   schedule.ConvertChangeToText(change, event.Entity.Type),
+		}
+		if !change.Date.IsZero() {
+			changePayload.Date = change.Date.Format("2006-01-02")
+		}
+		if change.Kind == types.Moved {
+			prevDay, prevSlot := change.PrevWeekDayNum, change.PrevLessonNum
+			changePayload.PrevDay, changePayload.PrevSlot = &prevDay, &prevSlot
+		}
+		payload.Changes = append(payload.Changes, changePayload)
+	}
+	return payload
+}